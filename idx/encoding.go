@@ -0,0 +1,118 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON implements json.Marshaler, encoding the ID as its string form
+// "env:type:object_id". The zero ID is encoded as null.
+func (id ID) MarshalJSON() ([]byte, error) {
+	if id.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(id.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, parsing a JSON string with ParseID.
+// A JSON null leaves the zero ID. Returns the parse error for malformed input.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*id = ID{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("ID must be a JSON string: %w", err)
+	}
+
+	parsed, err := ParseID(s)
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the ID as its string form
+// "env:type:object_id". The zero ID is encoded as empty text.
+func (id ID) MarshalText() ([]byte, error) {
+	if id.IsZero() {
+		return nil, nil
+	}
+
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the text with ParseID.
+// Empty text leaves the zero ID. Returns the parse error for malformed input.
+func (id *ID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = ID{}
+		return nil
+	}
+
+	parsed, err := ParseID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, encoding the ID as its string form so IDs
+// survive encoding/gob despite their unexported fields. The zero ID is encoded as
+// empty bytes.
+func (id ID) GobEncode() ([]byte, error) {
+	return id.MarshalText()
+}
+
+// GobDecode implements gob.GobDecoder, parsing the data with ParseID. Empty data
+// leaves the zero ID. Returns the parse error for malformed input.
+func (id *ID) GobDecode(data []byte) error {
+	return id.UnmarshalText(data)
+}
+
+// Value implements driver.Valuer, storing the ID in a text column as its string
+// form. The zero ID is stored as NULL.
+func (id ID) Value() (driver.Value, error) {
+	if id.IsZero() {
+		return nil, nil
+	}
+
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner, parsing a string or []byte column value with
+// ParseID. A NULL value leaves the zero ID.
+func (id *ID) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*id = ID{}
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ID", src)
+	}
+
+	parsed, err := ParseID(s)
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestID_JSON(t *testing.T) {
+	type payload struct {
+		Owner ID  `json:"owner"`
+		Ref   *ID `json:"ref,omitempty"`
+	}
+
+	id, err := ParseID("vibe:user:123")
+	if err != nil {
+		t.Fatalf("ParseID() unexpected error = %v", err)
+	}
+
+	t.Run("round trip as struct field", func(t *testing.T) {
+		data, err := json.Marshal(payload{Owner: id, Ref: &id})
+		if err != nil {
+			t.Fatalf("json.Marshal() unexpected error = %v", err)
+		}
+
+		want := `{"owner":"vibe:user:123","ref":"vibe:user:123"}`
+		if string(data) != want {
+			t.Errorf("json.Marshal() = %s, want %s", data, want)
+		}
+
+		var decoded payload
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal() unexpected error = %v", err)
+		}
+		if decoded.Owner != id || decoded.Ref == nil || *decoded.Ref != id {
+			t.Errorf("json.Unmarshal() = %+v, want owner and ref %v", decoded, id)
+		}
+	})
+
+	t.Run("zero ID and null", func(t *testing.T) {
+		data, err := json.Marshal(ID{})
+		if err != nil {
+			t.Fatalf("json.Marshal() unexpected error = %v", err)
+		}
+		if string(data) != "null" {
+			t.Errorf("json.Marshal(ID{}) = %s, want null", data)
+		}
+
+		decoded := id
+		if err := json.Unmarshal([]byte("null"), &decoded); err != nil {
+			t.Fatalf("json.Unmarshal(null) unexpected error = %v", err)
+		}
+		if !decoded.IsZero() {
+			t.Errorf("json.Unmarshal(null) = %v, want zero ID", decoded)
+		}
+	})
+
+	invalid := map[string]string{
+		"malformed ID": `"vibe:user"`,
+		"invalid type": `"vibe:1user:123"`,
+		"not a string": `123`,
+	}
+
+	for name, input := range invalid {
+		t.Run(name, func(t *testing.T) {
+			var decoded ID
+			if err := json.Unmarshal([]byte(input), &decoded); err == nil {
+				t.Errorf("json.Unmarshal(%s) expected error but got nil", input)
+			}
+		})
+	}
+}
+
+func TestID_Text(t *testing.T) {
+	id, err := ParseID("vibe:user:123")
+	if err != nil {
+		t.Fatalf("ParseID() unexpected error = %v", err)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		text, err := id.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() unexpected error = %v", err)
+		}
+		if string(text) != "vibe:user:123" {
+			t.Errorf("MarshalText() = %q, want %q", text, "vibe:user:123")
+		}
+
+		var decoded ID
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText() unexpected error = %v", err)
+		}
+		if decoded != id {
+			t.Errorf("UnmarshalText() = %v, want %v", decoded, id)
+		}
+	})
+
+	t.Run("zero ID and empty text", func(t *testing.T) {
+		text, err := ID{}.MarshalText()
+		if err != nil || len(text) != 0 {
+			t.Errorf("MarshalText() on zero ID = %q, %v, want empty, nil", text, err)
+		}
+
+		decoded := id
+		if err := decoded.UnmarshalText(nil); err != nil {
+			t.Fatalf("UnmarshalText(nil) unexpected error = %v", err)
+		}
+		if !decoded.IsZero() {
+			t.Errorf("UnmarshalText(nil) = %v, want zero ID", decoded)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		var decoded ID
+		if err := decoded.UnmarshalText([]byte("vibe:user")); err == nil {
+			t.Error("UnmarshalText() expected error but got nil")
+		}
+	})
+
+	t.Run("map keys", func(t *testing.T) {
+		data, err := json.Marshal(map[ID]int{id: 1})
+		if err != nil {
+			t.Fatalf("json.Marshal() unexpected error = %v", err)
+		}
+		if string(data) != `{"vibe:user:123":1}` {
+			t.Errorf("json.Marshal() = %s, want %s", data, `{"vibe:user:123":1}`)
+		}
+
+		var decoded map[ID]int
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal() unexpected error = %v", err)
+		}
+		if decoded[id] != 1 {
+			t.Errorf("json.Unmarshal() = %v, want key %v", decoded, id)
+		}
+	})
+}
+
+func TestID_Gob(t *testing.T) {
+	type payload struct {
+		Name  string
+		Owner ID
+		Refs  []ID
+		Empty ID
+	}
+
+	owner, err := ParseID("vibe:user:123")
+	if err != nil {
+		t.Fatalf("ParseID() unexpected error = %v", err)
+	}
+	ref, err := ParseID("dev:order:ord_1")
+	if err != nil {
+		t.Fatalf("ParseID() unexpected error = %v", err)
+	}
+
+	t.Run("round trip nested in struct", func(t *testing.T) {
+		in := payload{Name: "test", Owner: owner, Refs: []ID{ref, owner}}
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+			t.Fatalf("Encode() unexpected error = %v", err)
+		}
+
+		var out payload
+		if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+			t.Fatalf("Decode() unexpected error = %v", err)
+		}
+
+		if out.Name != in.Name || out.Owner != owner || len(out.Refs) != 2 || out.Refs[0] != ref || out.Refs[1] != owner {
+			t.Errorf("Decode() = %+v, want %+v", out, in)
+		}
+		if !out.Empty.IsZero() {
+			t.Errorf("Decode() Empty = %v, want zero ID", out.Empty)
+		}
+	})
+
+	t.Run("malformed bytes", func(t *testing.T) {
+		var id ID
+		err := id.GobDecode([]byte("vibe:user"))
+		_, wantErr := ParseID("vibe:user")
+		if err == nil || err.Error() != wantErr.Error() {
+			t.Errorf("GobDecode() error = %v, want %v", err, wantErr)
+		}
+	})
+}
+
+func TestID_Scan(t *testing.T) {
+	want, err := ParseID("vibe:user:123")
+	if err != nil {
+		t.Fatalf("ParseID() unexpected error = %v", err)
+	}
+
+	tests := map[string]struct {
+		src     any
+		want    ID
+		wantErr bool
+	}{
+		"string":       {src: "vibe:user:123", want: want},
+		"byte slice":   {src: []byte("vibe:user:123"), want: want},
+		"nil":          {src: nil, want: ID{}},
+		"malformed":    {src: "vibe:user", wantErr: true},
+		"unsupported":  {src: int64(1), wantErr: true},
+		"invalid type": {src: []byte("vibe:1user:123"), wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id := ID{env: "stale", objectType: "stale", objectID: "stale"}
+			err := id.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Scan(%v) expected error but got nil", tt.src)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%v) unexpected error = %v", tt.src, err)
+			}
+			if id != tt.want {
+				t.Errorf("Scan(%v) = %v, want %v", tt.src, id, tt.want)
+			}
+		})
+	}
+}
+
+func TestID_SQLRoundTrip(t *testing.T) {
+	db, err := sql.Open("idx_echo", "")
+	if err != nil {
+		t.Fatalf("sql.Open() unexpected error = %v", err)
+	}
+	defer db.Close()
+
+	id, err := ParseID("dev:order:ord_1")
+	if err != nil {
+		t.Fatalf("ParseID() unexpected error = %v", err)
+	}
+
+	for _, in := range []ID{id, {}} {
+		var out ID
+		if err := db.QueryRow("SELECT ?", in).Scan(&out); err != nil {
+			t.Fatalf("QueryRow(%v).Scan() unexpected error = %v", in, err)
+		}
+		if out != in {
+			t.Errorf("QueryRow(%v).Scan() = %v, want %v", in, out, in)
+		}
+	}
+}
+
+func init() {
+	sql.Register("idx_echo", echoDriver{})
+}
+
+// echoDriver is a minimal database/sql driver whose queries return their single
+// argument as a one-row, one-column result, exercising Valuer and Scanner.
+type echoDriver struct{}
+
+func (echoDriver) Open(string) (driver.Conn, error) { return echoConn{}, nil }
+
+type echoConn struct{}
+
+func (echoConn) Prepare(string) (driver.Stmt, error) { return echoStmt{}, nil }
+func (echoConn) Close() error                        { return nil }
+func (echoConn) Begin() (driver.Tx, error)           { return nil, errors.New("transactions not supported") }
+
+type echoStmt struct{}
+
+func (echoStmt) Close() error  { return nil }
+func (echoStmt) NumInput() int { return 1 }
+func (echoStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported")
+}
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{value: args[0]}, nil
+}
+
+type echoRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *echoRows) Columns() []string { return []string{"value"} }
+func (r *echoRows) Close() error      { return nil }
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Environment is a typed environment name. Use the Env constants for the common
+// environments to get compile-time checking, and CustomEnvironment for anything else.
+type Environment string
+
+// Known environments.
+const (
+	EnvLocal   Environment = "local"
+	EnvDev     Environment = "dev"
+	EnvTest    Environment = "test"
+	EnvStaging Environment = "staging"
+	EnvVibe    Environment = "vibe"
+)
+
+// knownEnvironments lists the environments accepted by ParseEnvironment.
+var knownEnvironments = []Environment{EnvLocal, EnvDev, EnvTest, EnvStaging, EnvVibe}
+
+// String returns the string representation of the Environment.
+func (e Environment) String() string {
+	return string(e)
+}
+
+// IsKnown reports whether e is one of the Env constants.
+func (e Environment) IsKnown() bool {
+	return slices.Contains(knownEnvironments, e)
+}
+
+// ParseEnvironment normalizes s like NewNamespace does ("prd" and empty become
+// "vibe", names are trimmed and lowercased) and returns the matching known
+// Environment. Returns an error for anything else, which catches typos such as
+// "stagign"; use CustomEnvironment to deliberately accept other names.
+func ParseEnvironment(s string) (Environment, error) {
+	env := Environment(normalizeEnvironment(s))
+	if !env.IsKnown() {
+		return "", fmt.Errorf("unknown environment %q", env)
+	}
+
+	return env, nil
+}
+
+// CustomEnvironment is the escape hatch for environments that are not one of the
+// Env constants. It normalizes s like ParseEnvironment and validates it with the
+// same rules as ParseNamespace, but does not require it to be known.
+func CustomEnvironment(s string) (Environment, error) {
+	env := normalizeEnvironment(s)
+	if err := validateEnvironment(env); err != nil {
+		return "", err
+	}
+
+	return Environment(env), nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import (
+	"testing"
+)
+
+func TestParseEnvironment(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected Environment
+		wantErr  bool
+	}{
+		"prd maps to vibe": {
+			input:    "prd",
+			expected: EnvVibe,
+		},
+		"uppercase PRD maps to vibe": {
+			input:    "PRD",
+			expected: EnvVibe,
+		},
+		"empty maps to vibe": {
+			input:    "",
+			expected: EnvVibe,
+		},
+		"known environment": {
+			input:    "staging",
+			expected: EnvStaging,
+		},
+		"mixed case known environment": {
+			input:    " Dev ",
+			expected: EnvDev,
+		},
+		"typo rejected": {
+			input:   "stagign",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			env, err := ParseEnvironment(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseEnvironment(%q) expected error, got %q", tt.input, env)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseEnvironment(%q) unexpected error = %v", tt.input, err)
+			}
+			if env != tt.expected {
+				t.Errorf("ParseEnvironment(%q) = %q, want %q", tt.input, env, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCustomEnvironment(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected Environment
+		wantErr  bool
+	}{
+		"unknown environment allowed": {
+			input:    "eu-west-2",
+			expected: "eu-west-2",
+		},
+		"normalized like ParseEnvironment": {
+			input:    "PRD",
+			expected: EnvVibe,
+		},
+		"invalid charset rejected": {
+			input:   "dev:user",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			env, err := CustomEnvironment(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("CustomEnvironment(%q) expected error, got %q", tt.input, env)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CustomEnvironment(%q) unexpected error = %v", tt.input, err)
+			}
+			if env != tt.expected {
+				t.Errorf("CustomEnvironment(%q) = %q, want %q", tt.input, env, tt.expected)
+			}
+			if env.IsKnown() != (env == EnvVibe) {
+				t.Errorf("CustomEnvironment(%q).IsKnown() = %v", tt.input, env.IsKnown())
+			}
+		})
+	}
+}
+
+func TestNewNamespaceEnv(t *testing.T) {
+	if got := NewNamespaceEnv(EnvStaging).Environment(); got != "staging" {
+		t.Errorf("NewNamespaceEnv(EnvStaging).Environment() = %q, want %q", got, "staging")
+	}
+	if got := NewNamespaceEnv("prd").Environment(); got != "vibe" {
+		t.Errorf("NewNamespaceEnv(\"prd\").Environment() = %q, want %q", got, "vibe")
+	}
+
+	custom, err := CustomEnvironment("eu-west-2")
+	if err != nil {
+		t.Fatalf("CustomEnvironment() unexpected error = %v", err)
+	}
+	if got := NewNamespaceEnv(custom).Environment(); got != "eu-west-2" {
+		t.Errorf("NewNamespaceEnv(custom).Environment() = %q, want %q", got, "eu-west-2")
+	}
+}
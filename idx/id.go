@@ -4,8 +4,14 @@
 package idx
 
 import (
+	"cmp"
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
+
+	"github.com/letmevibethatforyou/gox/slicex"
+	"github.com/segmentio/ksuid"
 )
 
 // ID represents an AWS-style identifier with environment, type, and object ID components.
@@ -27,11 +33,81 @@ func (id ID) Type() Type {
 	return id.objectType
 }
 
-// Value returns the object ID component of the ID.
-func (id ID) Value() string {
+// ObjectID returns the object ID component of the ID.
+func (id ID) ObjectID() string {
 	return id.objectID
 }
 
+// Timestamp returns the creation time embedded in an object ID generated by
+// NewID or NewIDWithTimestamp, which are KSUIDs, optionally with a shard prefix.
+// The time has one-second resolution. The bool is false if the object ID is not
+// a KSUID, for example a custom value passed to NewIDWithValue. As in Shard, any
+// leading text shaped like a shard prefix is skipped, so a custom value such as
+// "s1_<ksuid>" also reports the KSUID's time.
+func (id ID) Timestamp() (time.Time, bool) {
+	value := id.objectID
+	if _, rest, ok := splitShard(value); ok {
+		value = rest
+	}
+
+	k, err := ksuid.Parse(value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return k.Time(), true
+}
+
+// WithType returns a copy of the ID with its object type replaced by t, keeping
+// the environment and object ID. This supports migrations that rename entity types.
+// Returns an error if t is invalid; the original ID is never modified.
+func (id ID) WithType(t Type) (ID, error) {
+	if err := t.Validate(); err != nil {
+		return ID{}, fmt.Errorf("invalid object type: %w", err)
+	}
+
+	id.objectType = t
+	return id, nil
+}
+
+// Canonical returns a normalized copy of the ID with the environment and object
+// type lowercased, so that "Dev:User:123" and "dev:user:123" compare equal.
+// The object ID is case-sensitive and is left untouched.
+func (id ID) Canonical() ID {
+	id.env = strings.ToLower(id.env)
+	id.objectType = Type(strings.ToLower(string(id.objectType)))
+	return id
+}
+
+// HasPrefix reports whether the ID has exactly the given environment and type, the
+// in-memory counterpart of matching the string prefix returned by Namespace.Prefix.
+func (id ID) HasPrefix(env string, t Type) bool {
+	return id.env == env && id.objectType == t
+}
+
+// IsZero reports whether the ID is the zero value, with all three components
+// empty, distinguishing "no ID set" from a real ID. A partially populated ID is
+// not zero.
+func (id ID) IsZero() bool {
+	return id == ID{}
+}
+
+// Equal reports whether the two IDs have the same environment, type and object ID.
+// It is equivalent to ==; IDs are comparable and can be used as map keys.
+func (id ID) Equal(other ID) bool {
+	return id == other
+}
+
+// Compare orders IDs by environment, then type, then object ID, each compared
+// lexicographically. It returns -1, 0 or +1 and can be passed to slices.SortFunc.
+func (id ID) Compare(other ID) int {
+	return cmp.Or(
+		strings.Compare(id.env, other.env),
+		strings.Compare(string(id.objectType), string(other.objectType)),
+		strings.Compare(id.objectID, other.objectID),
+	)
+}
+
 // String returns the full string representation of the ID in the format: environment:type:object_id
 func (id ID) String() string {
 	return fmt.Sprintf("%s:%s:%s", id.env, id.objectType, id.objectID)
@@ -68,6 +144,179 @@ func ParseID(s string) (ID, error) {
 	}, nil
 }
 
+// MustParseID is like ParseID but panics if the string cannot be parsed.
+// It is intended for package-level variables and tests initialized from constant
+// strings, like regexp.MustCompile, and should not be used on request input.
+func MustParseID(s string) ID {
+	id, err := ParseID(s)
+	if err != nil {
+		panic(fmt.Errorf("idx: MustParseID(%q): %w", s, err))
+	}
+
+	return id
+}
+
+// ParseIDCanonical parses a string with ParseID and returns its Canonical form.
+func ParseIDCanonical(s string) (ID, error) {
+	id, err := ParseID(s)
+	if err != nil {
+		return ID{}, err
+	}
+
+	return id.Canonical(), nil
+}
+
+// shortEnvCodes are the one-letter codes Short uses for the known environments.
+var shortEnvCodes = map[Environment]byte{
+	EnvLocal:   'l',
+	EnvDev:     'd',
+	EnvTest:    't',
+	EnvStaging: 's',
+	EnvVibe:    'v',
+}
+
+// Short returns a compact, URL-safe reference to the ID suitable for short links.
+// Known environments are abbreviated to "~" and a one-letter code, followed by the
+// type and object ID separated by dots, e.g. "~vuser.2B5E5fLHQjw..." for
+// "vibe:user:2B5E5fLHQjw...", so references in known environments are shorter than
+// String. Other environments are written out as "env.type.object_id". Characters
+// other than letters, digits, '_' and '-' are percent-escaped. Short is reversible
+// with ParseShort and needs no lookup table.
+func (id ID) Short() string {
+	var b strings.Builder
+	if code, ok := shortEnvCodes[Environment(id.env)]; ok {
+		b.WriteByte('~')
+		b.WriteByte(code)
+	} else {
+		b.WriteString(shortEscape(id.env))
+		b.WriteByte('.')
+	}
+	b.WriteString(shortEscape(string(id.objectType)))
+	b.WriteByte('.')
+	b.WriteString(shortEscape(id.objectID))
+	return b.String()
+}
+
+// ParseShort parses a reference produced by Short back into an ID.
+// Returns an error if the input is not in the form Short produces or does not
+// decode to a valid ID.
+func ParseShort(s string) (ID, error) {
+	var env, rest string
+	if coded, ok := strings.CutPrefix(s, "~"); ok {
+		for e, code := range shortEnvCodes {
+			if coded != "" && coded[0] == code {
+				env, rest = string(e), coded[1:]
+			}
+		}
+		if env == "" {
+			return ID{}, fmt.Errorf("invalid short ID: unknown environment code in %q", s)
+		}
+	} else {
+		var found bool
+		if env, rest, found = strings.Cut(s, "."); !found {
+			return ID{}, fmt.Errorf("invalid short ID: expected parts separated by dots in %q", s)
+		}
+	}
+
+	objectType, objectID, found := strings.Cut(rest, ".")
+	if !found {
+		return ID{}, fmt.Errorf("invalid short ID: expected parts separated by dots in %q", s)
+	}
+
+	parts := []string{env, objectType, objectID}
+	for i, part := range parts {
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			return ID{}, fmt.Errorf("invalid short ID: %w", err)
+		}
+		parts[i] = unescaped
+	}
+
+	id, err := ParseID(strings.Join(parts, ":"))
+	if err != nil {
+		return ID{}, err
+	}
+
+	// Reject alternative spellings, so each ID has exactly one short reference
+	if id.Short() != s {
+		return ID{}, fmt.Errorf("invalid short ID: %q is not in canonical form", s)
+	}
+
+	return id, nil
+}
+
+// shortEscape percent-escapes every byte of s other than letters, digits, '_' and
+// '-', leaving '.' and '~' free to act as separators in Short.
+func shortEscape(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '_', c == '-':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0xF])
+		}
+	}
+	return b.String()
+}
+
+// Path returns the ID as a slash-separated path of the form env/type/object_id,
+// for laying out objects in filesystem or object-store hierarchies where colons
+// are problematic. Each component is path-escaped, so a slash inside a component
+// cannot introduce an extra level, and the dot segments "." and ".." are escaped
+// as "%2E" and "%2E%2E" so they cannot traverse directories. Reverse it with
+// ParsePath.
+func (id ID) Path() string {
+	return escapePathSegment(id.env) + "/" + escapePathSegment(string(id.objectType)) + "/" + escapePathSegment(id.objectID)
+}
+
+// ParsePath parses a path produced by Path back into an ID.
+// Returns an error if the path does not have exactly three components, a component
+// is an unescaped "." or "..", a component is not validly escaped, or the resulting
+// ID would not be accepted by ParseID, for example because a component contains a
+// colon.
+func ParsePath(p string) (ID, error) {
+	parts := strings.Split(p, "/")
+	if len(parts) != 3 {
+		return ID{}, fmt.Errorf("invalid ID path: expected 3 parts separated by slashes, got %d parts", len(parts))
+	}
+
+	for i, part := range parts {
+		if part == "." || part == ".." {
+			return ID{}, fmt.Errorf("invalid ID path: unescaped dot segment %q", part)
+		}
+
+		unescaped, err := url.PathUnescape(part)
+		if err != nil {
+			return ID{}, fmt.Errorf("invalid ID path: %w", err)
+		}
+		parts[i] = unescaped
+	}
+
+	// Rebuild the string form so path input gets the same checks as ParseID,
+	// including rejecting colons inside components
+	id, err := ParseID(strings.Join(parts, ":"))
+	if err != nil {
+		return ID{}, fmt.Errorf("invalid ID path: %w", err)
+	}
+
+	return id, nil
+}
+
+// escapePathSegment path-escapes s, additionally escaping the dot segments "." and
+// "..", which url.PathEscape leaves as is.
+func escapePathSegment(s string) string {
+	if s == "." || s == ".." {
+		return strings.Repeat("%2E", len(s))
+	}
+	return url.PathEscape(s)
+}
+
 // Validate checks that all components of the ID are valid.
 // Returns an error if any component is invalid or empty.
 func (id ID) Validate() error {
@@ -85,3 +334,9 @@ func (id ID) Validate() error {
 
 	return nil
 }
+
+// UniqueIDs returns a new slice containing each distinct ID once, preserving the
+// order in which IDs were first seen.
+func UniqueIDs(ids []ID) []ID {
+	return slicex.Unique(ids)
+}
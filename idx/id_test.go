@@ -4,8 +4,12 @@
 package idx
 
 import (
+	"errors"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestID_Env(t *testing.T) {
@@ -38,18 +42,241 @@ func TestID_Type(t *testing.T) {
 	}
 }
 
-func TestID_Value(t *testing.T) {
+func TestID_ObjectID(t *testing.T) {
 	id := ID{
 		env:        "test-env",
 		objectType: Type("user"),
 		objectID:   "test-value-123",
 	}
 
-	result := id.Value()
+	result := id.ObjectID()
 	expected := "test-value-123"
 
 	if result != expected {
-		t.Errorf("Value() = %q, want %q", result, expected)
+		t.Errorf("ObjectID() = %q, want %q", result, expected)
+	}
+}
+
+func TestID_Timestamp(t *testing.T) {
+	ns := NewNamespace("dev")
+
+	t.Run("generated value", func(t *testing.T) {
+		before := time.Now().Truncate(time.Second)
+		id, err := ns.NewID(Type("user"))
+		if err != nil {
+			t.Fatalf("NewID() unexpected error = %v", err)
+		}
+		after := time.Now()
+
+		ts, ok := id.Timestamp()
+		if !ok {
+			t.Fatal("Timestamp() ok = false, want true")
+		}
+		if ts.Before(before) || ts.After(after) {
+			t.Errorf("Timestamp() = %v, want between %v and %v", ts, before, after)
+		}
+	})
+
+	t.Run("explicit timestamp with shard prefix", func(t *testing.T) {
+		sharded, err := NewNamespaceSharded("dev", 7)
+		if err != nil {
+			t.Fatalf("NewNamespaceSharded() unexpected error = %v", err)
+		}
+
+		want := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+		id, err := sharded.NewIDWithTimestamp(Type("user"), want)
+		if err != nil {
+			t.Fatalf("NewIDWithTimestamp() unexpected error = %v", err)
+		}
+
+		ts, ok := id.Timestamp()
+		if !ok || !ts.Equal(want) {
+			t.Errorf("Timestamp() = %v, %v, want %v, true", ts, ok, want)
+		}
+	})
+
+	t.Run("custom value", func(t *testing.T) {
+		id, err := ns.NewIDWithValue(Type("user"), "custom_123")
+		if err != nil {
+			t.Fatalf("NewIDWithValue() unexpected error = %v", err)
+		}
+
+		if ts, ok := id.Timestamp(); ok {
+			t.Errorf("Timestamp() = %v, true, want false", ts)
+		}
+	})
+}
+
+func TestID_WithType(t *testing.T) {
+	original := ID{
+		env:        "dev",
+		objectType: Type("order"),
+		objectID:   "ord_123",
+	}
+
+	t.Run("retypes keeping env and object ID", func(t *testing.T) {
+		retyped, err := original.WithType(Type("purchase"))
+		if err != nil {
+			t.Fatalf("WithType() unexpected error = %v", err)
+		}
+
+		if retyped.String() != "dev:purchase:ord_123" {
+			t.Errorf("WithType().String() = %q, want %q", retyped.String(), "dev:purchase:ord_123")
+		}
+		if original.Type() != Type("order") {
+			t.Errorf("original Type() = %q, want %q", original.Type(), "order")
+		}
+	})
+
+	t.Run("invalid type errors without mutating", func(t *testing.T) {
+		_, err := original.WithType(Type("1purchase"))
+		if err == nil {
+			t.Fatal("WithType() expected error but got nil")
+		}
+		if !strings.Contains(err.Error(), "invalid object type: type must start with a letter") {
+			t.Errorf("WithType() error = %v, want invalid type error", err)
+		}
+		if original.String() != "dev:order:ord_123" {
+			t.Errorf("original String() = %q, want %q", original.String(), "dev:order:ord_123")
+		}
+	})
+}
+
+func TestID_Canonical(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"mixed case env and type": {
+			input: "Dev:User:123",
+			want:  "dev:user:123",
+		},
+		"object ID case preserved": {
+			input: "PROD:Order:Ord_AbC",
+			want:  "prod:order:Ord_AbC",
+		},
+		"already canonical": {
+			input: "vibe:user:abc",
+			want:  "vibe:user:abc",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := ParseID(tt.input)
+			if err != nil {
+				t.Fatalf("ParseID() unexpected error = %v", err)
+			}
+
+			if got := id.Canonical().String(); got != tt.want {
+				t.Errorf("Canonical() = %q, want %q", got, tt.want)
+			}
+			if id.String() != tt.input {
+				t.Errorf("original String() = %q, want %q", id.String(), tt.input)
+			}
+
+			parsed, err := ParseIDCanonical(tt.input)
+			if err != nil {
+				t.Fatalf("ParseIDCanonical() unexpected error = %v", err)
+			}
+			if parsed.String() != tt.want {
+				t.Errorf("ParseIDCanonical() = %q, want %q", parsed.String(), tt.want)
+			}
+		})
+	}
+
+	t.Run("case variants compare equal", func(t *testing.T) {
+		a, _ := ParseIDCanonical("Dev:User:123")
+		b, _ := ParseIDCanonical("dev:user:123")
+		if a != b {
+			t.Errorf("ParseIDCanonical() = %v and %v, want equal", a, b)
+		}
+	})
+
+	t.Run("invalid input errors", func(t *testing.T) {
+		if _, err := ParseIDCanonical("Dev:User"); err == nil {
+			t.Error("ParseIDCanonical() expected error but got nil")
+		}
+	})
+}
+
+func TestID_Compare(t *testing.T) {
+	parse := func(s string) ID {
+		id, err := ParseID(s)
+		if err != nil {
+			t.Fatalf("ParseID(%q) unexpected error = %v", s, err)
+		}
+		return id
+	}
+
+	ids := []ID{
+		parse("vibe:user:b"),
+		parse("dev:user:z"),
+		parse("vibe:order:a"),
+		parse("vibe:user:a"),
+		parse("dev:user:z"),
+		parse("dev:account:z"),
+	}
+
+	strs := func(ids []ID) []string {
+		out := make([]string, len(ids))
+		for i, id := range ids {
+			out[i] = id.String()
+		}
+		return out
+	}
+
+	slices.SortFunc(ids, ID.Compare)
+	got := strs(ids)
+	want := []string{"dev:account:z", "dev:user:z", "dev:user:z", "vibe:order:a", "vibe:user:a", "vibe:user:b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortFunc(ID.Compare) = %v, want %v", got, want)
+	}
+
+	deduped := strs(slices.CompactFunc(ids, ID.Equal))
+	wantDeduped := []string{"dev:account:z", "dev:user:z", "vibe:order:a", "vibe:user:a", "vibe:user:b"}
+	if !reflect.DeepEqual(deduped, wantDeduped) {
+		t.Errorf("CompactFunc(ID.Equal) = %v, want %v", deduped, wantDeduped)
+	}
+
+	a, b := parse("dev:user:1"), parse("dev:user:2")
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Errorf("Compare() = %d, %d, %d, want -1, 1, 0", a.Compare(b), b.Compare(a), a.Compare(a))
+	}
+	if a.Equal(b) || !a.Equal(parse("dev:user:1")) {
+		t.Errorf("Equal() returned wrong result for %v and %v", a, b)
+	}
+}
+
+func TestID_IsZero(t *testing.T) {
+	tests := map[string]struct {
+		id       ID
+		expected bool
+	}{
+		"zero value": {
+			id:       ID{},
+			expected: true,
+		},
+		"fully populated": {
+			id:       ID{env: "vibe", objectType: Type("user"), objectID: "123"},
+			expected: false,
+		},
+		"only environment set": {
+			id:       ID{env: "vibe"},
+			expected: false,
+		},
+		"missing object ID": {
+			id:       ID{env: "vibe", objectType: Type("user")},
+			expected: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.id.IsZero(); got != tt.expected {
+				t.Errorf("IsZero() = %v, want %v", got, tt.expected)
+			}
+		})
 	}
 }
 
@@ -225,6 +452,163 @@ func TestParseID(t *testing.T) {
 	}
 }
 
+func TestMustParseID(t *testing.T) {
+	if got := MustParseID("vibe:user:123"); got.String() != "vibe:user:123" {
+		t.Errorf("MustParseID() = %q, want %q", got.String(), "vibe:user:123")
+	}
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok {
+			t.Fatalf("MustParseID() panic = %v, want error", r)
+		}
+		if want := `idx: MustParseID("vibe:user"): `; !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("MustParseID() panic = %q, want prefix %q", err, want)
+		}
+		_, parseErr := ParseID("vibe:user")
+		if unwrapped := errors.Unwrap(err); unwrapped == nil || unwrapped.Error() != parseErr.Error() {
+			t.Errorf("MustParseID() panic wraps %v, want %v", unwrapped, parseErr)
+		}
+	}()
+	MustParseID("vibe:user")
+}
+
+func TestID_Short(t *testing.T) {
+	ids := []ID{
+		{env: "dev", objectType: "user", objectID: "123"},
+		{env: "dev", objectType: "user", objectID: "124"},
+		{env: "vibe", objectType: "user", objectID: "123"},
+		{env: "dev", objectType: "order", objectID: "2B5E5fLHQjw1234567890123456"},
+		{env: "eu-west-2", objectType: "user", objectID: "a.b/c~d e%f"},
+		{env: "v", objectType: "user", objectID: "123"},
+	}
+
+	seen := make(map[string]ID)
+	for _, id := range ids {
+		short := id.Short()
+
+		if prev, ok := seen[short]; ok {
+			t.Errorf("Short() collision between %v and %v: %q", prev, id, short)
+		}
+		seen[short] = id
+
+		if strings.ContainsAny(short, "+/=: ") {
+			t.Errorf("Short() = %q, want URL-safe characters only", short)
+		}
+
+		parsed, err := ParseShort(short)
+		if err != nil {
+			t.Fatalf("ParseShort(%q) unexpected error = %v", short, err)
+		}
+		if parsed != id {
+			t.Errorf("ParseShort(%q) = %v, want %v", short, parsed, id)
+		}
+	}
+}
+
+func TestID_Short_Length(t *testing.T) {
+	for _, env := range knownEnvironments {
+		id, err := NewNamespaceEnv(env).NewID(Type("user"))
+		if err != nil {
+			t.Fatalf("NewID() unexpected error = %v", err)
+		}
+
+		if short := id.Short(); len(short) >= len(id.String()) {
+			t.Errorf("len(Short()) = %d for %q, want less than len(String()) = %d", len(short), short, len(id.String()))
+		}
+	}
+}
+
+func TestParseShort_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no separators":          "devuser123",
+		"missing object ID part": "~vuser",
+		"unknown env code":       "~xuser.123",
+		"bare env marker":        "~",
+		"invalid escape":         "dev.user.%zz",
+		"empty object ID":        "~vuser.",
+		"needless escape":        "~vus%65r.123",
+		"empty string":           "",
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseShort(input); err == nil {
+				t.Errorf("ParseShort(%q) expected error but got nil", input)
+			}
+		})
+	}
+}
+
+func TestID_Path(t *testing.T) {
+	tests := map[string]struct {
+		id   ID
+		want string
+	}{
+		"simple": {
+			id:   ID{env: "vibe", objectType: Type("user"), objectID: "123"},
+			want: "vibe/user/123",
+		},
+		"slash in object ID is escaped": {
+			id:   ID{env: "dev", objectType: Type("file"), objectID: "docs/readme.md"},
+			want: "dev/file/docs%2Freadme.md",
+		},
+		"space and percent are escaped": {
+			id:   ID{env: "dev", objectType: Type("doc"), objectID: "50% off"},
+			want: "dev/doc/50%25%20off",
+		},
+		"dot segments are escaped": {
+			id:   ID{env: ".", objectType: Type("file"), objectID: ".."},
+			want: "%2E/file/%2E%2E",
+		},
+		"dots inside a component are kept": {
+			id:   ID{env: "dev", objectType: Type("file"), objectID: "..a.b"},
+			want: "dev/file/..a.b",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tt.id.Path()
+			if got != tt.want {
+				t.Errorf("Path() = %q, want %q", got, tt.want)
+			}
+
+			parsed, err := ParsePath(got)
+			if err != nil {
+				t.Fatalf("ParsePath() unexpected error = %v", err)
+			}
+			if parsed != tt.id {
+				t.Errorf("ParsePath(%q) = %v, want %v", got, parsed, tt.id)
+			}
+		})
+	}
+}
+
+func TestParsePath_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"too few parts":   "vibe/user",
+		"too many parts":  "vibe/user/a/b",
+		"bad escape":      "vibe/user/%zz",
+		"empty object ID": "vibe/user/",
+		"invalid type":    "vibe/1user/123",
+		"parent segment":  "vibe/user/..",
+		"current segment": "./user/123",
+		"colon in env":    "a:b/user/x",
+		"colon in ID":     "vibe/user/x:y",
+		"escaped colon":   "vibe/user/x%3Ay",
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParsePath(input); err == nil {
+				t.Errorf("ParsePath(%q) expected error but got nil", input)
+			}
+		})
+	}
+}
+
 func TestID_Validate(t *testing.T) {
 	tests := map[string]struct {
 		id      ID
@@ -401,8 +785,8 @@ func TestID_Integration(t *testing.T) {
 	if autoID.Type() != objectType {
 		t.Errorf("NewID().Type() = %q, want %q", autoID.Type(), objectType)
 	}
-	if autoID.Value() == "" {
-		t.Errorf("NewID().Value() should not be empty")
+	if autoID.ObjectID() == "" {
+		t.Errorf("NewID().ObjectID() should not be empty")
 	}
 
 	// Test parsing the auto-generated ID
@@ -426,3 +810,20 @@ func TestID_Integration(t *testing.T) {
 		t.Errorf("NewIDWithValue().String() = %q, want %q", customID.String(), expectedString)
 	}
 }
+
+func TestUniqueIDs(t *testing.T) {
+	a := ID{env: "dev", objectType: "user", objectID: "1"}
+	b := ID{env: "dev", objectType: "user", objectID: "2"}
+	c := ID{env: "vibe", objectType: "user", objectID: "1"}
+
+	result := UniqueIDs([]ID{b, a, b, c, a, c})
+	expected := []ID{b, a, c}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("UniqueIDs() = %v, want %v", result, expected)
+	}
+
+	if result := UniqueIDs(nil); result != nil {
+		t.Errorf("UniqueIDs(nil) = %v, want nil", result)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import (
+	"strings"
+	"sync"
+)
+
+// Interner deduplicates the string storage of ID environments and types, so that
+// IDs with equal env/type prefixes share the same backing memory. This reduces the
+// memory held by services that keep millions of IDs sharing a few env/type prefixes,
+// and releases the larger strings that parsed components would otherwise reference.
+// Object IDs are nearly always unique, so they are copied but never stored.
+//
+// The zero value is ready to use. An Interner is safe for concurrent use and grows
+// with the number of distinct environments and types it has seen.
+type Interner struct {
+	mu      sync.Mutex
+	strings map[string]string
+}
+
+// Intern returns an ID equal to id whose environment and type are backed by
+// canonical, shared strings and whose object ID is a private copy.
+func (in *Interner) Intern(id ID) ID {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return ID{
+		env:        in.intern(id.env),
+		objectType: Type(in.intern(string(id.objectType))),
+		objectID:   strings.Clone(id.objectID),
+	}
+}
+
+// intern returns the canonical copy of s. Callers must hold in.mu.
+func (in *Interner) intern(s string) string {
+	if in.strings == nil {
+		in.strings = make(map[string]string)
+	}
+
+	if canonical, ok := in.strings[s]; ok {
+		return canonical
+	}
+
+	// Clone so the canonical string does not pin a larger string it was sliced from
+	canonical := strings.Clone(s)
+	in.strings[canonical] = canonical
+	return canonical
+}
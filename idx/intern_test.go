@@ -0,0 +1,126 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+func TestInterner_Intern(t *testing.T) {
+	var in Interner
+
+	a, err := ParseID("vibe:user:123")
+	if err != nil {
+		t.Fatalf("ParseID() unexpected error = %v", err)
+	}
+	b, err := ParseID("vibe:user:123")
+	if err != nil {
+		t.Fatalf("ParseID() unexpected error = %v", err)
+	}
+
+	ia := in.Intern(a)
+	ib := in.Intern(b)
+
+	if ia != a || ib != b || ia != ib {
+		t.Errorf("Intern() changed ID values: %v, %v", ia, ib)
+	}
+
+	if unsafe.StringData(ia.env) != unsafe.StringData(ib.env) {
+		t.Error("Intern() env components do not share storage")
+	}
+	if unsafe.StringData(string(ia.objectType)) != unsafe.StringData(string(ib.objectType)) {
+		t.Error("Intern() type components do not share storage")
+	}
+	if unsafe.StringData(ia.objectID) == unsafe.StringData(a.objectID) {
+		t.Error("Intern() object ID still references the parsed string")
+	}
+
+	// Components are shared across different IDs too
+	c := in.Intern(ID{env: "vibe", objectType: "order", objectID: "456"})
+	if unsafe.StringData(c.env) != unsafe.StringData(ia.env) {
+		t.Error("Intern() env not shared across different IDs")
+	}
+
+	// Object IDs are not retained, so unique IDs do not grow the interner
+	for i := range 100 {
+		in.Intern(ID{env: "vibe", objectType: "user", objectID: fmt.Sprint(i)})
+	}
+	if got := len(in.strings); got != 3 {
+		t.Errorf("Interner holds %d strings, want 3", got)
+	}
+}
+
+func TestInterner_Concurrent(t *testing.T) {
+	var in Interner
+	var wg sync.WaitGroup
+
+	results := make([]ID, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, _ := ParseID(fmt.Sprintf("vibe:user:%d", 42))
+			results[i] = in.Intern(id)
+		}()
+	}
+	wg.Wait()
+
+	for _, id := range results {
+		if id != results[0] {
+			t.Errorf("Intern() = %v, want %v", id, results[0])
+		}
+		if unsafe.StringData(id.env) != unsafe.StringData(results[0].env) {
+			t.Error("Intern() concurrent results do not share storage")
+		}
+	}
+}
+
+// BenchmarkInterner reports the heap retained per ID for a large set of parsed IDs
+// with unique object IDs that share a common env/type prefix, with and without
+// interning. The interner is kept alive while measuring so its own storage counts.
+func BenchmarkInterner(b *testing.B) {
+	const n = 100_000
+
+	build := func(intern bool) ([]ID, *Interner) {
+		in := new(Interner)
+		ids := make([]ID, n)
+		for i := range ids {
+			id, _ := ParseID(fmt.Sprintf("vibe:subscription_item:%d", i))
+			if intern {
+				id = in.Intern(id)
+			}
+			ids[i] = id
+		}
+		return ids, in
+	}
+
+	for _, intern := range []bool{false, true} {
+		name := "plain"
+		if intern {
+			name = "interned"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			var retained uint64
+			for i := 0; i < b.N; i++ {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+
+				ids, in := build(intern)
+
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				retained += after.HeapAlloc - before.HeapAlloc
+				runtime.KeepAlive(ids)
+				runtime.KeepAlive(in)
+			}
+			b.ReportMetric(float64(retained)/float64(b.N)/n, "B/id")
+		})
+	}
+}
@@ -5,22 +5,125 @@ package idx
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/segmentio/ksuid"
 )
 
 // Namespace represents an environment context for creating IDs.
 // It encapsulates the environment name and provides methods to create new IDs within that environment.
+//
+// Copies of a Namespace share the same type registry and GetOrCreateID cache, so
+// types registered through one copy are visible to all others. Namespaces must be
+// created with NewNamespace.
+//
+// Namespaces can be compared with ==, but equality means the same instance: a
+// namespace equals its copies, which share its type registry, cache and options,
+// while two separately created namespaces never compare equal, even for the same
+// environment (NewNamespace("dev") != NewNamespace("dev")). Compare Environment
+// values to test whether two namespaces belong to the same environment.
 type Namespace struct {
 	environment string
+	types       *TypeRegistry
+	ids         *idCache
+	strictTypes bool
+	shardPrefix string
+	opts        *namespaceOptions
+}
+
+// namespaceOptions holds the func-valued settings of a Namespace. They live behind
+// a pointer so Namespace stays comparable with ==. A namespaceOptions is never
+// modified once set; the With methods install an updated copy instead.
+type namespaceOptions struct {
+	generator func() string
+	validator func(string) error
+}
+
+// withOptions returns a copy of the namespace with update applied to a copy of its
+// options, leaving the original namespace unchanged.
+func (n Namespace) withOptions(update func(*namespaceOptions)) Namespace {
+	var opts namespaceOptions
+	if n.opts != nil {
+		opts = *n.opts
+	}
+
+	update(&opts)
+	n.opts = &opts
+	return n
+}
+
+// options returns the namespace's options, or the zero options if none are set.
+func (n Namespace) options() namespaceOptions {
+	if n.opts == nil {
+		return namespaceOptions{}
+	}
+	return *n.opts
 }
 
 // NewNamespace creates a new Namespace with the given environment.
 // Special handling: "prd" and empty string environments are normalized to "vibe".
 func NewNamespace(environment string) Namespace {
 	env := normalizeEnvironment(environment)
-	return Namespace{environment: env}
+	return Namespace{environment: env, types: NewTypeRegistry(), ids: newIDCache()}
+}
+
+// NewNamespaceEnv creates a new Namespace for a typed Environment, such as EnvDev
+// or a value returned by ParseEnvironment or CustomEnvironment.
+func NewNamespaceEnv(env Environment) Namespace {
+	return NewNamespace(string(env))
+}
+
+// NewNamespaceSharded creates a new Namespace whose generated object IDs embed the
+// given shard as a prefix (e.g. "s03_<ksuid>"), so IDs can be routed to a
+// partitioned datastore. Use Shard to extract the shard back from an ID.
+// Returns an error if shard is negative.
+func NewNamespaceSharded(environment string, shard int) (Namespace, error) {
+	if shard < 0 {
+		return Namespace{}, fmt.Errorf("shard cannot be negative: %d", shard)
+	}
+
+	n := NewNamespace(environment)
+	n.shardPrefix = fmt.Sprintf("s%02d_", shard)
+	return n, nil
+}
+
+// Shard returns the shard embedded in an ID generated by a sharded namespace.
+// The bool is false if the object ID does not carry a shard prefix.
+//
+// Shard only inspects the object ID, so any value shaped like a shard prefix
+// ("s" followed by digits and an underscore, then more text) is reported as
+// sharded, whoever produced it: a custom value such as "s1_abc" passed to
+// NewIDWithValue, or "s1_0" from NewSequentialGenerator("s1"), yields (1, true).
+// Only rely on Shard for IDs known to come from NewNamespaceSharded.
+func Shard(id ID) (int, bool) {
+	shard, _, ok := splitShard(id.objectID)
+	return shard, ok
+}
+
+// splitShard separates a "sNN_" shard prefix from an object ID value.
+func splitShard(value string) (shard int, rest string, ok bool) {
+	prefix, rest, found := strings.Cut(value, "_")
+	if !found || rest == "" || len(prefix) < 2 || prefix[0] != 's' {
+		return 0, "", false
+	}
+
+	digits := prefix[1:]
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, "", false
+		}
+	}
+
+	shard, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, "", false
+	}
+	return shard, rest, true
 }
 
 // Environment returns the normalized environment name for this namespace.
@@ -28,18 +131,302 @@ func (n Namespace) Environment() string {
 	return n.environment
 }
 
+// Owns reports whether the ID belongs to this namespace's environment. Use it to
+// reject cross-environment IDs at service boundaries.
+func (n Namespace) Owns(id ID) bool {
+	return id.env == n.environment
+}
+
+// Prefix returns the string prefix shared by all IDs of the object type in this
+// namespace, such as "vibe:user:", for prefix range scans over a keyspace keyed by
+// ID strings. The trailing separator ensures "vibe:user:" does not also match
+// "vibe:username:...". Returns an error if the object type is invalid.
+func (n Namespace) Prefix(objectType Type) (string, error) {
+	if err := objectType.Validate(); err != nil {
+		return "", fmt.Errorf("invalid object type: %w", err)
+	}
+
+	return n.environment + ":" + string(objectType) + ":", nil
+}
+
+// ParseID parses a string with the package-level ParseID and additionally rejects
+// IDs whose environment is not the namespace's normalized environment, to keep IDs
+// from untrusted sources from crossing environments. The ID is then checked with
+// ValidateID, so strict types and registered formats apply.
+func (n Namespace) ParseID(s string) (ID, error) {
+	id, err := ParseID(s)
+	if err != nil {
+		return ID{}, err
+	}
+
+	if !n.Owns(id) {
+		return ID{}, fmt.Errorf("ID environment %q does not match namespace %q", id.env, n.environment)
+	}
+
+	if err := n.ValidateID(id); err != nil {
+		return ID{}, err
+	}
+
+	return id, nil
+}
+
+// RegisterType declares that the namespace manages the given object type.
+// Registering the same type more than once has no effect. Returns an error if the
+// namespace has no type registry because it was not created with NewNamespace.
+func (n Namespace) RegisterType(t Type) error {
+	if n.types == nil {
+		return fmt.Errorf("namespace has no type registry: create it with NewNamespace")
+	}
+
+	n.types.Register(t)
+	return nil
+}
+
+// KnownTypes returns the registered object types in registration order.
+func (n Namespace) KnownTypes() []Type {
+	return n.types.Types()
+}
+
+// RegisterTypeFormat requires object IDs of type t to match re, so malformed external
+// IDs are caught at the boundary. It also registers t as a known type.
+// Types without a registered format accept any non-empty value. Returns an error if
+// the namespace has no type registry because it was not created with NewNamespace.
+func (n Namespace) RegisterTypeFormat(t Type, re *regexp.Regexp) error {
+	if n.types == nil {
+		return fmt.Errorf("namespace has no type registry: create it with NewNamespace")
+	}
+
+	n.types.registerFormat(t, re)
+	return nil
+}
+
+// ValidateID checks an ID, typically one obtained from ParseID, against the rules
+// of this namespace: the object type must be registered in strict mode and the
+// object ID must match any format registered for its type. A validator set with
+// WithValueValidator is not applied, since the ID may have been generated by the
+// namespace itself.
+func (n Namespace) ValidateID(id ID) error {
+	if err := id.Validate(); err != nil {
+		return err
+	}
+
+	return n.checkValue(id.objectType, id.objectID)
+}
+
+// WithStrictTypes returns a copy of the namespace that, when strict is true,
+// rejects IDs whose object type has not been registered with RegisterType.
+// The copy shares the type registry of the original namespace.
+func (n Namespace) WithStrictTypes(strict bool) Namespace {
+	n.strictTypes = strict
+	return n
+}
+
+// WithTypeRegistry returns a copy of the namespace that uses r as its type
+// registry and rejects object types not registered in r, like WithStrictTypes(true).
+// A registry can be shared by several namespaces and populated up front, so typos
+// such as Type("usr") fail in NewID and NewIDWithValue. Formats registered with
+// RegisterTypeFormat on the copy are recorded in r. A nil r restores a fresh,
+// non-strict registry.
+func (n Namespace) WithTypeRegistry(r *TypeRegistry) Namespace {
+	if r == nil {
+		n.types = NewTypeRegistry()
+		n.strictTypes = false
+		return n
+	}
+
+	n.types = r
+	n.strictTypes = true
+	return n
+}
+
+// WithGenerator returns a copy of the namespace that generates object ID values
+// with gen instead of KSUIDs, for example to use UUIDv7, nanoid or a deterministic
+// counter in tests. Generated values are still checked like custom values, so gen
+// returning an empty string makes NewID fail. A nil gen restores the default.
+// NewIDWithTimestamp always uses KSUIDs, since it embeds the time in the value.
+func (n Namespace) WithGenerator(gen func() string) Namespace {
+	return n.withOptions(func(opts *namespaceOptions) {
+		opts.generator = gen
+	})
+}
+
+// WithValueValidator returns a copy of the namespace that runs validate on
+// caller-supplied object ID values, to enforce house rules such as a lowercase
+// alphanumeric charset centrally. It applies only to values passed to
+// NewIDWithValue, not to values the namespace generates itself or to IDs checked
+// with ValidateID or ParseID, so it can be combined with the default KSUID
+// generator and the namespace accepts its own IDs back. The validator's
+// error is returned wrapped with the rejected value. The non-empty check always
+// applies; a nil validate restores the default.
+func (n Namespace) WithValueValidator(validate func(value string) error) Namespace {
+	return n.withOptions(func(opts *namespaceOptions) {
+		opts.validator = validate
+	})
+}
+
+// NewSequentialGenerator returns a generator for WithGenerator that produces
+// "prefix_0", "prefix_1", ... in order, so tests can assert on exact ID strings.
+// With an empty prefix it produces "0", "1", .... Each generator has its own
+// counter and is safe for concurrent use.
+//
+//	ns := idx.NewNamespace("test").WithGenerator(idx.NewSequentialGenerator("user"))
+//	id, _ := ns.NewID(idx.Type("user")) // "test:user:user_0"
+func NewSequentialGenerator(prefix string) func() string {
+	var next atomic.Uint64
+	if prefix != "" {
+		prefix += "_"
+	}
+
+	return func() string {
+		return prefix + strconv.FormatUint(next.Add(1)-1, 10)
+	}
+}
+
 // NewID creates a new ID within this namespace using the specified object type.
 // The object ID component is automatically generated to ensure uniqueness.
 // Returns an error if the object type is invalid.
 func (n Namespace) NewID(objectType Type) (ID, error) {
-	value := ksuid.New()
-	return n.NewIDWithValue(objectType, value.String())
+	return n.newID(objectType, n.generateValue())
+}
+
+// GetOrCreateID returns the ID previously created for the external key and object
+// type, or creates and remembers a new one with NewID. Repeated calls with the same
+// key, for example redeliveries of a webhook keyed by an external event ID, return
+// the same ID. It is safe for concurrent use, and copies of the namespace share
+// the cache. The cache is unbounded and entries are never evicted, so it should
+// only hold keys for the lifetime of a bounded process or batch.
+// Returns an error if the object type is invalid or the external key is empty.
+func (n Namespace) GetOrCreateID(objectType Type, externalKey string) (ID, error) {
+	if externalKey == "" {
+		return ID{}, fmt.Errorf("external key cannot be empty")
+	}
+
+	if n.ids == nil {
+		return ID{}, fmt.Errorf("namespace has no ID cache: create it with NewNamespace")
+	}
+
+	// Check the type before the cache, which copies with other rules share
+	if err := objectType.Validate(); err != nil {
+		return ID{}, fmt.Errorf("invalid object type: %w", err)
+	}
+
+	if err := n.checkType(objectType); err != nil {
+		return ID{}, err
+	}
+
+	return n.ids.getOrCreate(idCacheKey{objectType: objectType, externalKey: externalKey}, func() (ID, error) {
+		return n.NewID(objectType)
+	})
+}
+
+// Factory validates the object type once and returns a function that generates new
+// IDs of that type, avoiding repeated type validation in hot loops.
+// Returns an error immediately if the object type is invalid or, in strict mode,
+// unregistered. The returned function still enforces any registered value format.
+func (n Namespace) Factory(objectType Type) (func() (ID, error), error) {
+	if err := objectType.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid object type: %w", err)
+	}
+
+	if err := n.checkType(objectType); err != nil {
+		return nil, err
+	}
+
+	return func() (ID, error) {
+		value := n.generateValue()
+		if value == "" {
+			return ID{}, fmt.Errorf("value cannot be empty")
+		}
+
+		if err := n.checkFormat(objectType, value); err != nil {
+			return ID{}, err
+		}
+
+		return ID{
+			env:        n.environment,
+			objectType: objectType,
+			objectID:   value,
+		}, nil
+	}, nil
+}
+
+// NewIDs creates count new IDs of the same object type, validating the type only
+// once. The generated object IDs are checked to be unique, so a custom generator
+// that repeats a value is reported as an error rather than silently producing
+// duplicate IDs. Returns nil for a count of zero, and an error if the count is
+// negative or the object type is invalid.
+func (n Namespace) NewIDs(objectType Type, count int) ([]ID, error) {
+	if count < 0 {
+		return nil, fmt.Errorf("count cannot be negative: %d", count)
+	}
+
+	factory, err := n.Factory(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, nil
+	}
+
+	ids := make([]ID, count)
+	seen := make(map[string]struct{}, count)
+	for i := range ids {
+		id, err := factory()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[id.objectID]; ok {
+			return nil, fmt.Errorf("generated duplicate object ID %q", id.objectID)
+		}
+		seen[id.objectID] = struct{}{}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// NewIDWithTimestamp creates a new ID whose auto-generated value embeds the given
+// creation time instead of the current time, so imported historical records sort by
+// their original creation time. The value is still unique; the embedded timestamp
+// has one-second resolution. Returns an error if the object type is invalid or the
+// time is outside the range a KSUID can represent.
+func (n Namespace) NewIDWithTimestamp(objectType Type, t time.Time) (ID, error) {
+	// ksuid silently wraps times outside its range, so reject them explicitly
+	if t.Before(ksuid.Nil.Time()) || t.After(ksuid.Max.Time()) {
+		return ID{}, fmt.Errorf("timestamp %s is outside the supported range %s to %s",
+			t.Format(time.RFC3339), ksuid.Nil.Time().UTC().Format(time.RFC3339), ksuid.Max.Time().UTC().Format(time.RFC3339))
+	}
+
+	value, err := ksuid.NewRandomWithTime(t)
+	if err != nil {
+		return ID{}, fmt.Errorf("generating value: %w", err)
+	}
+
+	return n.newID(objectType, n.shardPrefix+value.String())
 }
 
 // NewIDWithValue creates a new ID within this namespace using the specified object type and custom value.
 // This allows callers to provide their own object ID value instead of using auto-generation.
-// Returns an error if the object type is invalid or the value is empty.
+// Returns an error if the object type is invalid, the value is empty, or the value
+// is rejected by a validator set with WithValueValidator.
 func (n Namespace) NewIDWithValue(objectType Type, value string) (ID, error) {
+	id, err := n.newID(objectType, value)
+	if err != nil {
+		return ID{}, err
+	}
+
+	if err := n.checkValidator(value); err != nil {
+		return ID{}, err
+	}
+
+	return id, nil
+}
+
+// newID creates an ID from a generated or caller-supplied value, applying every
+// check except the value validator.
+func (n Namespace) newID(objectType Type, value string) (ID, error) {
 	if err := objectType.Validate(); err != nil {
 		return ID{}, fmt.Errorf("invalid object type: %w", err)
 	}
@@ -48,6 +435,10 @@ func (n Namespace) NewIDWithValue(objectType Type, value string) (ID, error) {
 		return ID{}, fmt.Errorf("value cannot be empty")
 	}
 
+	if err := n.checkValue(objectType, value); err != nil {
+		return ID{}, err
+	}
+
 	return ID{
 		env:        n.environment,
 		objectType: objectType,
@@ -55,11 +446,98 @@ func (n Namespace) NewIDWithValue(objectType Type, value string) (ID, error) {
 	}, nil
 }
 
+// generateValue returns a new unique object ID value, including any shard prefix.
+// Returns an empty string if a custom generator produced an empty value.
+func (n Namespace) generateValue() string {
+	generator := n.options().generator
+	if generator == nil {
+		return n.shardPrefix + ksuid.New().String()
+	}
+
+	value := generator()
+	if value == "" {
+		return ""
+	}
+	return n.shardPrefix + value
+}
+
+// checkValue applies the namespace's type registrations to an object type and value.
+func (n Namespace) checkValue(objectType Type, value string) error {
+	if err := n.checkType(objectType); err != nil {
+		return err
+	}
+
+	return n.checkFormat(objectType, value)
+}
+
+// checkType rejects unregistered object types in strict mode.
+func (n Namespace) checkType(objectType Type) error {
+	if n.strictTypes && !n.types.Has(objectType) {
+		return fmt.Errorf("object type %q is not registered in namespace %q", objectType, n.environment)
+	}
+
+	return nil
+}
+
+// checkFormat rejects values that do not match the format registered for the type.
+func (n Namespace) checkFormat(objectType Type, value string) error {
+	if re := n.types.format(objectType); re != nil && !re.MatchString(value) {
+		return fmt.Errorf("value %q does not match format %q for type %q", value, re.String(), objectType)
+	}
+
+	return nil
+}
+
+// checkValidator rejects caller-supplied values that fail the namespace's value
+// validator.
+func (n Namespace) checkValidator(value string) error {
+	if validator := n.options().validator; validator != nil {
+		if err := validator(value); err != nil {
+			return fmt.Errorf("invalid value %q: %w", value, err)
+		}
+	}
+
+	return nil
+}
+
+// maxEnvironmentLength is the maximum number of characters ParseNamespace accepts
+// in an environment name.
+const maxEnvironmentLength = 32
+
+// environmentRegex validates normalized environment names.
+var environmentRegex = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// ParseNamespace is the validating counterpart of NewNamespace. It normalizes the
+// environment the same way and returns an error unless the result contains only
+// letters, digits and hyphens and is at most 32 characters long.
+func ParseNamespace(environment string) (Namespace, error) {
+	if err := validateEnvironment(normalizeEnvironment(environment)); err != nil {
+		return Namespace{}, err
+	}
+
+	return NewNamespace(environment), nil
+}
+
+// validateEnvironment checks a normalized environment name against the charset
+// and length rules enforced by ParseNamespace and CustomEnvironment.
+func validateEnvironment(env string) error {
+	if len(env) > maxEnvironmentLength {
+		return fmt.Errorf("environment cannot be longer than %d characters", maxEnvironmentLength)
+	}
+
+	if !environmentRegex.MatchString(env) {
+		return fmt.Errorf("environment %q must contain only letters, numbers, and hyphens", env)
+	}
+
+	return nil
+}
+
 // normalizeEnvironment applies special transformation rules to environment names.
-// Both "prd" and empty string are converted to "vibe" for consistency.
-// All other environment names are trimmed of whitespace but otherwise unchanged.
+// Names are trimmed of whitespace and lowercased, so casing variants such as "Dev"
+// and "dev" are the same environment. Both "prd" and empty string are then converted
+// to "vibe" for consistency.
 func normalizeEnvironment(env string) string {
-	env = strings.TrimSpace(env)
+	env = strings.ToLower(strings.TrimSpace(env))
 
 	if env == "" || env == "prd" {
 		return "vibe"
@@ -67,3 +545,39 @@ func normalizeEnvironment(env string) string {
 
 	return env
 }
+
+// idCacheKey identifies an ID created by GetOrCreateID.
+type idCacheKey struct {
+	objectType  Type
+	externalKey string
+}
+
+// idCache remembers the IDs created by GetOrCreateID. It is safe for concurrent use.
+type idCache struct {
+	mu  sync.Mutex
+	ids map[idCacheKey]ID
+}
+
+func newIDCache() *idCache {
+	return &idCache{ids: make(map[idCacheKey]ID)}
+}
+
+// getOrCreate returns the cached ID for key, or calls create and caches its result.
+// The lock is held while creating so concurrent callers for the same key agree.
+// Errors are not cached.
+func (c *idCache) getOrCreate(key idCacheKey, create func() (ID, error)) (ID, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if id, ok := c.ids[key]; ok {
+		return id, nil
+	}
+
+	id, err := create()
+	if err != nil {
+		return ID{}, err
+	}
+
+	c.ids[key] = id
+	return id, nil
+}
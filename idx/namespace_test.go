@@ -1,8 +1,16 @@
 package idx
 
 import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/segmentio/ksuid"
 )
 
 func TestNewNamespace(t *testing.T) {
@@ -136,8 +144,8 @@ func TestNamespace_NewID(t *testing.T) {
 				t.Errorf("NewID().Type() = %q, want %q", id.Type(), tt.objectType)
 			}
 
-			if id.Value() == "" {
-				t.Errorf("NewID().Value() should not be empty")
+			if id.ObjectID() == "" {
+				t.Errorf("NewID().ObjectID() should not be empty")
 			}
 
 			// Verify string format
@@ -226,8 +234,8 @@ func TestNamespace_NewIDWithValue(t *testing.T) {
 				t.Errorf("NewIDWithValue().Type() = %q, want %q", id.Type(), tt.objectType)
 			}
 
-			if id.Value() != tt.value {
-				t.Errorf("NewIDWithValue().Value() = %q, want %q", id.Value(), tt.value)
+			if id.ObjectID() != tt.value {
+				t.Errorf("NewIDWithValue().ObjectID() = %q, want %q", id.ObjectID(), tt.value)
 			}
 
 			// Verify string format
@@ -272,6 +280,18 @@ func TestNormalizeEnvironment(t *testing.T) {
 			input:    "  test-env  ",
 			expected: "test-env",
 		},
+		"uppercase PRD becomes vibe": {
+			input:    "PRD",
+			expected: "vibe",
+		},
+		"mixed case Prd becomes vibe": {
+			input:    " Prd ",
+			expected: "vibe",
+		},
+		"mixed case custom env is lowercased": {
+			input:    "My-Staging",
+			expected: "my-staging",
+		},
 	}
 
 	for name, tt := range tests {
@@ -285,6 +305,58 @@ func TestNormalizeEnvironment(t *testing.T) {
 }
 
 // Test that NewID generates unique values on multiple calls
+func TestParseNamespace(t *testing.T) {
+	tests := map[string]struct {
+		environment string
+		expected    string
+		wantErr     string
+	}{
+		"PRD becomes vibe": {
+			environment: "PRD",
+			expected:    "vibe",
+		},
+		"empty becomes vibe": {
+			environment: "",
+			expected:    "vibe",
+		},
+		"mixed case custom env": {
+			environment: "EU-West-2",
+			expected:    "eu-west-2",
+		},
+		"colon rejected": {
+			environment: "dev:user",
+			wantErr:     "must contain only letters, numbers, and hyphens",
+		},
+		"underscore rejected": {
+			environment: "my_env",
+			wantErr:     "must contain only letters, numbers, and hyphens",
+		},
+		"too long": {
+			environment: strings.Repeat("a", 33),
+			wantErr:     "environment cannot be longer than 32 characters",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ns, err := ParseNamespace(tt.environment)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseNamespace(%q) error = %v, want error containing %q", tt.environment, err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseNamespace(%q) unexpected error = %v", tt.environment, err)
+			}
+			if ns.Environment() != tt.expected {
+				t.Errorf("ParseNamespace(%q).Environment() = %q, want %q", tt.environment, ns.Environment(), tt.expected)
+			}
+		})
+	}
+}
+
 func TestNamespace_NewID_Uniqueness(t *testing.T) {
 	ns := NewNamespace("test")
 	objectType := Type("user")
@@ -304,9 +376,870 @@ func TestNamespace_NewID_Uniqueness(t *testing.T) {
 		ids[idString] = true
 
 		// Ensure the value part is unique
-		if ids[id.Value()] {
-			t.Errorf("NewID() generated duplicate value: %s", id.Value())
+		if ids[id.ObjectID()] {
+			t.Errorf("NewID() generated duplicate value: %s", id.ObjectID())
+		}
+		ids[id.ObjectID()] = true
+	}
+}
+
+func TestNamespace_GetOrCreateID(t *testing.T) {
+	ns := NewNamespace("dev")
+
+	t.Run("same key returns same ID under concurrent access", func(t *testing.T) {
+		const workers = 16
+		keys := []string{"evt_1", "evt_2", "evt_3"}
+
+		results := make([][]ID, len(keys))
+		for k := range results {
+			results[k] = make([]ID, workers)
+		}
+
+		var wg sync.WaitGroup
+		for k, key := range keys {
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					id, err := ns.GetOrCreateID(Type("event"), key)
+					if err != nil {
+						t.Errorf("GetOrCreateID() unexpected error = %v", err)
+					}
+					results[k][w] = id
+				}()
+			}
+		}
+		wg.Wait()
+
+		seen := make(map[ID]string)
+		for k, ids := range results {
+			for _, id := range ids {
+				if id != ids[0] {
+					t.Errorf("GetOrCreateID(%q) = %v, want %v", keys[k], id, ids[0])
+				}
+			}
+			if other, ok := seen[ids[0]]; ok {
+				t.Errorf("GetOrCreateID(%q) returned the same ID as key %q", keys[k], other)
+			}
+			seen[ids[0]] = keys[k]
+		}
+	})
+
+	t.Run("keys are scoped by type and shared by copies", func(t *testing.T) {
+		event, err := ns.GetOrCreateID(Type("event"), "ext_1")
+		if err != nil {
+			t.Fatalf("GetOrCreateID() unexpected error = %v", err)
+		}
+		delivery, err := ns.GetOrCreateID(Type("delivery"), "ext_1")
+		if err != nil {
+			t.Fatalf("GetOrCreateID() unexpected error = %v", err)
+		}
+		if event.ObjectID() == delivery.ObjectID() {
+			t.Errorf("GetOrCreateID() reused %q across types", event.ObjectID())
+		}
+
+		again, err := ns.WithStrictTypes(false).GetOrCreateID(Type("event"), "ext_1")
+		if err != nil {
+			t.Fatalf("GetOrCreateID() unexpected error = %v", err)
+		}
+		if again != event {
+			t.Errorf("GetOrCreateID() on copy = %v, want %v", again, event)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		if _, err := ns.GetOrCreateID(Type("event"), ""); err == nil {
+			t.Error("GetOrCreateID() with empty key expected error but got nil")
+		}
+		if _, err := ns.GetOrCreateID(Type("1event"), "ext_2"); err == nil {
+			t.Error("GetOrCreateID() with invalid type expected error but got nil")
+		}
+		if _, err := (Namespace{}).GetOrCreateID(Type("event"), "ext_2"); err == nil {
+			t.Error("GetOrCreateID() on zero namespace expected error but got nil")
+		}
+	})
+	t.Run("strict copy rejects cached unregistered type", func(t *testing.T) {
+		if _, err := ns.GetOrCreateID(Type("usr"), "ext_3"); err != nil {
+			t.Fatalf("GetOrCreateID() unexpected error = %v", err)
+		}
+
+		strict := ns.WithStrictTypes(true)
+		_, err := strict.GetOrCreateID(Type("usr"), "ext_3")
+		if err == nil || !strings.Contains(err.Error(), `object type "usr" is not registered`) {
+			t.Errorf("GetOrCreateID() on strict copy error = %v, want unregistered type error", err)
+		}
+	})
+}
+
+func TestNamespace_WithGenerator(t *testing.T) {
+	counter := 0
+	gen := func() string {
+		counter++
+		return "seq_" + strconv.Itoa(counter)
+	}
+
+	ns := NewNamespace("dev").WithGenerator(gen)
+
+	t.Run("custom values appear in IDs", func(t *testing.T) {
+		for _, want := range []string{"seq_1", "seq_2"} {
+			id, err := ns.NewID(Type("user"))
+			if err != nil {
+				t.Fatalf("NewID() unexpected error = %v", err)
+			}
+			if id.ObjectID() != want {
+				t.Errorf("NewID().ObjectID() = %q, want %q", id.ObjectID(), want)
+			}
+		}
+
+		factory, err := ns.Factory(Type("user"))
+		if err != nil {
+			t.Fatalf("Factory() unexpected error = %v", err)
+		}
+		id, err := factory()
+		if err != nil {
+			t.Fatalf("factory() unexpected error = %v", err)
+		}
+		if id.ObjectID() != "seq_3" {
+			t.Errorf("factory().ObjectID() = %q, want %q", id.ObjectID(), "seq_3")
+		}
+	})
+
+	t.Run("combined with shard prefix", func(t *testing.T) {
+		sharded, err := NewNamespaceSharded("dev", 4)
+		if err != nil {
+			t.Fatalf("NewNamespaceSharded() unexpected error = %v", err)
+		}
+
+		id, err := sharded.WithGenerator(func() string { return "fixed" }).NewID(Type("user"))
+		if err != nil {
+			t.Fatalf("NewID() unexpected error = %v", err)
+		}
+		if id.ObjectID() != "s04_fixed" {
+			t.Errorf("NewID().ObjectID() = %q, want %q", id.ObjectID(), "s04_fixed")
+		}
+	})
+
+	t.Run("empty generated value errors", func(t *testing.T) {
+		empty := NewNamespace("dev").WithGenerator(func() string { return "" })
+		if _, err := empty.NewID(Type("user")); err == nil {
+			t.Error("NewID() expected error but got nil")
+		}
+
+		factory, err := empty.Factory(Type("user"))
+		if err != nil {
+			t.Fatalf("Factory() unexpected error = %v", err)
+		}
+		if _, err := factory(); err == nil {
+			t.Error("factory() expected error but got nil")
+		}
+	})
+
+	t.Run("default remains ksuid", func(t *testing.T) {
+		id, err := NewNamespace("dev").NewID(Type("user"))
+		if err != nil {
+			t.Fatalf("NewID() unexpected error = %v", err)
+		}
+		if _, err := ksuid.Parse(id.ObjectID()); err != nil {
+			t.Errorf("NewID().ObjectID() = %q, want a KSUID: %v", id.ObjectID(), err)
+		}
+	})
+
+	t.Run("namespace stays comparable", func(t *testing.T) {
+		base := NewNamespace("dev")
+		withGen := base.WithGenerator(gen)
+
+		if copied := withGen; copied != withGen {
+			t.Error("copy of namespace with generator != original, want equal")
+		}
+		if withGen == base {
+			t.Error("WithGenerator() == original namespace, want different")
+		}
+		if NewNamespace("dev") == NewNamespace("dev") {
+			t.Error("separately created namespaces compare equal, want different instances")
+		}
+		if NewNamespace("dev").Environment() != base.Environment() {
+			t.Error("Environment() differs for namespaces of the same environment")
+		}
+
+		// The original namespace keeps generating KSUIDs
+		id, err := base.NewID(Type("user"))
+		if err != nil {
+			t.Fatalf("NewID() unexpected error = %v", err)
+		}
+		if _, err := ksuid.Parse(id.ObjectID()); err != nil {
+			t.Errorf("NewID().ObjectID() = %q, want a KSUID: %v", id.ObjectID(), err)
+		}
+	})
+}
+
+func TestNewSequentialGenerator(t *testing.T) {
+	t.Run("values increment", func(t *testing.T) {
+		ns := NewNamespace("test").WithGenerator(NewSequentialGenerator("user"))
+
+		for _, want := range []string{"test:user:user_0", "test:user:user_1", "test:user:user_2"} {
+			id, err := ns.NewID(Type("user"))
+			if err != nil {
+				t.Fatalf("NewID() unexpected error = %v", err)
+			}
+			if id.String() != want {
+				t.Errorf("NewID() = %q, want %q", id.String(), want)
+			}
+		}
+	})
+
+	t.Run("separate generators do not interfere", func(t *testing.T) {
+		a := NewNamespace("test").WithGenerator(NewSequentialGenerator("a"))
+		b := NewNamespace("test").WithGenerator(NewSequentialGenerator("b"))
+
+		ids := make([]string, 0, 4)
+		for _, ns := range []Namespace{a, b, a, b} {
+			id, err := ns.NewID(Type("user"))
+			if err != nil {
+				t.Fatalf("NewID() unexpected error = %v", err)
+			}
+			ids = append(ids, id.ObjectID())
+		}
+
+		want := []string{"a_0", "b_0", "a_1", "b_1"}
+		if !reflect.DeepEqual(ids, want) {
+			t.Errorf("NewID() object IDs = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("empty prefix", func(t *testing.T) {
+		gen := NewSequentialGenerator("")
+		if got := gen() + "," + gen(); got != "0,1" {
+			t.Errorf("gen() = %q, want %q", got, "0,1")
+		}
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		ns := NewNamespace("test").WithGenerator(NewSequentialGenerator("c"))
+
+		const goroutines, perGoroutine = 8, 100
+		var mu sync.Mutex
+		seen := make(map[string]bool)
+		var wg sync.WaitGroup
+		for range goroutines {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range perGoroutine {
+					id, err := ns.NewID(Type("user"))
+					if err != nil {
+						t.Errorf("NewID() unexpected error = %v", err)
+						return
+					}
+					mu.Lock()
+					seen[id.ObjectID()] = true
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(seen) != goroutines*perGoroutine {
+			t.Errorf("NewID() produced %d unique values, want %d", len(seen), goroutines*perGoroutine)
+		}
+		if !seen["c_0"] || !seen["c_799"] {
+			t.Error("NewID() values do not cover c_0 through c_799")
+		}
+	})
+}
+
+func TestNamespace_WithValueValidator(t *testing.T) {
+	errUppercase := errors.New("must be lowercase")
+	lowercase := func(value string) error {
+		if value != strings.ToLower(value) {
+			return errUppercase
+		}
+		return nil
+	}
+
+	ns := NewNamespace("dev").WithValueValidator(lowercase)
+
+	t.Run("rejects invalid custom value", func(t *testing.T) {
+		_, err := ns.NewIDWithValue(Type("user"), "ABC123")
+		if !errors.Is(err, errUppercase) {
+			t.Fatalf("NewIDWithValue() error = %v, want wrapped %v", err, errUppercase)
+		}
+		if !strings.Contains(err.Error(), `invalid value "ABC123"`) {
+			t.Errorf("NewIDWithValue() error = %q, want it to name the value", err)
+		}
+	})
+
+	t.Run("accepts valid custom value", func(t *testing.T) {
+		id, err := ns.NewIDWithValue(Type("user"), "abc123")
+		if err != nil {
+			t.Fatalf("NewIDWithValue() unexpected error = %v", err)
+		}
+		if id.ObjectID() != "abc123" {
+			t.Errorf("NewIDWithValue().ObjectID() = %q, want %q", id.ObjectID(), "abc123")
+		}
+	})
+
+	t.Run("does not apply to generated values", func(t *testing.T) {
+		// Default KSUIDs contain uppercase letters
+		if _, err := ns.NewID(Type("user")); err != nil {
+			t.Errorf("NewID() unexpected error = %v", err)
+		}
+		if _, err := ns.NewIDs(Type("user"), 10); err != nil {
+			t.Errorf("NewIDs() unexpected error = %v", err)
+		}
+
+		factory, err := ns.Factory(Type("user"))
+		if err != nil {
+			t.Fatalf("Factory() unexpected error = %v", err)
+		}
+		if _, err := factory(); err != nil {
+			t.Errorf("factory() unexpected error = %v", err)
+		}
+
+		upper := ns.WithGenerator(func() string { return "GEN" })
+		if _, err := upper.NewID(Type("user")); err != nil {
+			t.Errorf("NewID() with custom generator unexpected error = %v", err)
+		}
+	})
+
+	t.Run("generated IDs parse back", func(t *testing.T) {
+		id, err := ns.NewID(Type("user"))
+		if err != nil {
+			t.Fatalf("NewID() unexpected error = %v", err)
+		}
+
+		parsed, err := ns.ParseID(id.String())
+		if err != nil {
+			t.Fatalf("ParseID(%q) unexpected error = %v", id, err)
+		}
+		if parsed != id {
+			t.Errorf("ParseID(%q) = %v, want %v", id, parsed, id)
+		}
+	})
+
+	t.Run("default namespace accepts uppercase", func(t *testing.T) {
+		if _, err := NewNamespace("dev").NewIDWithValue(Type("user"), "ABC123"); err != nil {
+			t.Errorf("NewIDWithValue() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("empty value still rejected", func(t *testing.T) {
+		permissive := NewNamespace("dev").WithValueValidator(func(string) error { return nil })
+		if _, err := permissive.NewIDWithValue(Type("user"), ""); err == nil {
+			t.Error("NewIDWithValue() with empty value expected error but got nil")
+		}
+	})
+}
+
+func TestNamespace_RegisterType(t *testing.T) {
+	ns := NewNamespace("dev")
+
+	if types := ns.KnownTypes(); types != nil {
+		t.Errorf("KnownTypes() on new namespace = %v, want nil", types)
+	}
+
+	ns.RegisterType(Type("user"))
+	ns.RegisterType(Type("order"))
+	ns.RegisterType(Type("user")) // duplicate registration is ignored
+
+	expected := []Type{"user", "order"}
+	if !reflect.DeepEqual(ns.KnownTypes(), expected) {
+		t.Errorf("KnownTypes() = %v, want %v", ns.KnownTypes(), expected)
+	}
+
+	// Copies share the registry
+	strict := ns.WithStrictTypes(true)
+	strict.RegisterType(Type("invoice"))
+	if len(ns.KnownTypes()) != 3 {
+		t.Errorf("KnownTypes() = %v, want registration visible through copy", ns.KnownTypes())
+	}
+
+	// A zero namespace has no registry to record types in
+	var zero Namespace
+	if err := zero.RegisterType(Type("user")); err == nil {
+		t.Error("RegisterType() on zero Namespace expected error but got nil")
+	}
+	if err := zero.RegisterTypeFormat(Type("user"), regexp.MustCompile(`^u[0-9]+$`)); err == nil {
+		t.Error("RegisterTypeFormat() on zero Namespace expected error but got nil")
+	}
+	if types := zero.KnownTypes(); types != nil {
+		t.Errorf("KnownTypes() on zero Namespace = %v, want nil", types)
+	}
+}
+
+func TestNamespace_WithStrictTypes(t *testing.T) {
+	ns := NewNamespace("dev")
+	ns.RegisterType(Type("user"))
+	strict := ns.WithStrictTypes(true)
+
+	if _, err := strict.NewID(Type("user")); err != nil {
+		t.Errorf("NewID() with registered type unexpected error = %v", err)
+	}
+
+	_, err := strict.NewID(Type("order"))
+	if err == nil {
+		t.Fatal("NewID() with unregistered type expected error but got nil")
+	}
+	if !strings.Contains(err.Error(), `object type "order" is not registered`) {
+		t.Errorf("NewID() error = %v, want unregistered type error", err)
+	}
+
+	// Non-strict namespaces accept any valid type
+	if _, err := ns.NewID(Type("order")); err != nil {
+		t.Errorf("NewID() on non-strict namespace unexpected error = %v", err)
+	}
+}
+
+func TestNamespace_RegisterTypeFormat(t *testing.T) {
+	ns := NewNamespace("dev")
+	ns.RegisterTypeFormat(Type("invoice"), regexp.MustCompile(`^inv_[0-9]+$`))
+
+	t.Run("conforming value", func(t *testing.T) {
+		id, err := ns.NewIDWithValue(Type("invoice"), "inv_123")
+		if err != nil {
+			t.Fatalf("NewIDWithValue() unexpected error = %v", err)
+		}
+		if id.String() != "dev:invoice:inv_123" {
+			t.Errorf("NewIDWithValue().String() = %q, want %q", id.String(), "dev:invoice:inv_123")
+		}
+	})
+
+	t.Run("non-conforming value", func(t *testing.T) {
+		_, err := ns.NewIDWithValue(Type("invoice"), "INV-123")
+		if err == nil {
+			t.Fatal("NewIDWithValue() expected error but got nil")
+		}
+		if !strings.Contains(err.Error(), `does not match format "^inv_[0-9]+$"`) {
+			t.Errorf("NewIDWithValue() error = %v, want format error", err)
+		}
+	})
+
+	t.Run("unregistered types are unrestricted", func(t *testing.T) {
+		if _, err := ns.NewIDWithValue(Type("user"), "ANY-value"); err != nil {
+			t.Errorf("NewIDWithValue() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("parsed IDs are validated against the format", func(t *testing.T) {
+		good, err := ParseID("dev:invoice:inv_42")
+		if err != nil {
+			t.Fatalf("ParseID() unexpected error = %v", err)
+		}
+		if err := ns.ValidateID(good); err != nil {
+			t.Errorf("ValidateID() unexpected error = %v", err)
+		}
+
+		bad, err := ParseID("dev:invoice:42")
+		if err != nil {
+			t.Fatalf("ParseID() unexpected error = %v", err)
+		}
+		if err := ns.ValidateID(bad); err == nil {
+			t.Error("ValidateID() expected error but got nil")
+		}
+	})
+
+	t.Run("registers the type", func(t *testing.T) {
+		if !reflect.DeepEqual(ns.KnownTypes(), []Type{"invoice"}) {
+			t.Errorf("KnownTypes() = %v, want [invoice]", ns.KnownTypes())
+		}
+	})
+}
+
+func TestNewNamespaceSharded(t *testing.T) {
+	t.Run("round-trips the shard", func(t *testing.T) {
+		for _, shard := range []int{0, 3, 42, 127} {
+			ns, err := NewNamespaceSharded("dev", shard)
+			if err != nil {
+				t.Fatalf("NewNamespaceSharded() unexpected error = %v", err)
+			}
+
+			id, err := ns.NewID(Type("user"))
+			if err != nil {
+				t.Fatalf("NewID() unexpected error = %v", err)
+			}
+
+			got, ok := Shard(id)
+			if !ok || got != shard {
+				t.Errorf("Shard(%q) = %d, %v, want %d, true", id, got, ok, shard)
+			}
+
+			// The prefixed object ID must survive a parse roundtrip
+			parsed, err := ParseID(id.String())
+			if err != nil {
+				t.Fatalf("ParseID(%q) unexpected error = %v", id, err)
+			}
+			if parsed != id {
+				t.Errorf("ParseID(%q) = %v, want %v", id, parsed, id)
+			}
+		}
+	})
+
+	t.Run("prefix format", func(t *testing.T) {
+		ns, _ := NewNamespaceSharded("dev", 3)
+		id, _ := ns.NewID(Type("user"))
+		if !strings.HasPrefix(id.ObjectID(), "s03_") {
+			t.Errorf("NewID().ObjectID() = %q, want prefix %q", id.ObjectID(), "s03_")
+		}
+	})
+
+	t.Run("negative shard", func(t *testing.T) {
+		if _, err := NewNamespaceSharded("dev", -1); err == nil {
+			t.Error("NewNamespaceSharded() expected error but got nil")
+		}
+	})
+
+	t.Run("unsharded values", func(t *testing.T) {
+		for _, value := range []string{"2B5E5fLHQjw1234567890123456", "s_abc", "sx1_abc", "s01_", "custom_value"} {
+			id := ID{env: "dev", objectType: "user", objectID: value}
+			if _, ok := Shard(id); ok {
+				t.Errorf("Shard(%q) reported a shard, want none", value)
+			}
+		}
+	})
+
+	t.Run("shard-shaped values are reported as sharded", func(t *testing.T) {
+		ns := NewNamespace("dev").WithGenerator(NewSequentialGenerator("s1"))
+		id, err := ns.NewID(Type("user"))
+		if err != nil {
+			t.Fatalf("NewID() unexpected error = %v", err)
+		}
+
+		if shard, ok := Shard(id); !ok || shard != 1 {
+			t.Errorf("Shard(%q) = %d, %v, want 1, true", id, shard, ok)
+		}
+	})
+}
+
+func TestNamespace_Owns(t *testing.T) {
+	tests := map[string]struct {
+		environment string
+		id          string
+		expected    bool
+	}{
+		"matching environment": {
+			environment: "staging",
+			id:          "staging:user:123",
+			expected:    true,
+		},
+		"mismatching environment": {
+			environment: "prd",
+			id:          "staging:user:123",
+			expected:    false,
+		},
+		"normalized vibe environment": {
+			environment: "prd",
+			id:          "vibe:user:123",
+			expected:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := ParseID(tt.id)
+			if err != nil {
+				t.Fatalf("ParseID(%q) unexpected error = %v", tt.id, err)
+			}
+
+			ns := NewNamespace(tt.environment)
+			if result := ns.Owns(id); result != tt.expected {
+				t.Errorf("NewNamespace(%q).Owns(%q) = %v, want %v", tt.environment, tt.id, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNamespace_ParseID(t *testing.T) {
+	tests := map[string]struct {
+		env     string
+		input   string
+		wantErr string
+	}{
+		"matching env": {
+			env:   "dev",
+			input: "dev:user:123",
+		},
+		"mismatched env": {
+			env:     "vibe",
+			input:   "dev:user:123",
+			wantErr: `ID environment "dev" does not match namespace "vibe"`,
+		},
+		"prd namespace accepts vibe IDs": {
+			env:   "prd",
+			input: "vibe:user:123",
+		},
+		"prd IDs are not normalized": {
+			env:     "prd",
+			input:   "prd:user:123",
+			wantErr: `ID environment "prd" does not match namespace "vibe"`,
+		},
+		"structural errors come from ParseID": {
+			env:     "dev",
+			input:   "dev:user",
+			wantErr: "invalid ID format",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := NewNamespace(tt.env).ParseID(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseID() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseID() unexpected error = %v", err)
+			}
+			if id.String() != tt.input {
+				t.Errorf("ParseID() = %q, want %q", id.String(), tt.input)
+			}
+		})
+	}
+}
+
+func TestNamespace_ParseID_Rules(t *testing.T) {
+	formatted := NewNamespace("vibe")
+	formatted.RegisterTypeFormat(Type("invoice"), regexp.MustCompile(`^inv_[0-9]+$`))
+
+	registry := NewTypeRegistry()
+	registry.Register(Type("user"))
+
+	strict := NewNamespace("vibe").WithStrictTypes(true)
+	strict.RegisterType(Type("user"))
+
+	lowercase := NewNamespace("vibe").WithValueValidator(func(value string) error {
+		if value != strings.ToLower(value) {
+			return errors.New("must be lowercase")
+		}
+		return nil
+	})
+
+	tests := map[string]struct {
+		ns      Namespace
+		input   string
+		wantErr string
+	}{
+		"matches registered format": {
+			ns:    formatted,
+			input: "vibe:invoice:inv_42",
+		},
+		"violates registered format": {
+			ns:      formatted,
+			input:   "vibe:invoice:garbage",
+			wantErr: "does not match format",
+		},
+		"unregistered type in strict mode": {
+			ns:      strict,
+			input:   "vibe:order:123",
+			wantErr: "is not registered",
+		},
+		"registered type in strict mode": {
+			ns:    strict,
+			input: "vibe:user:123",
+		},
+		"type missing from type registry": {
+			ns:      NewNamespace("vibe").WithTypeRegistry(registry),
+			input:   "vibe:usr:123",
+			wantErr: "is not registered",
+		},
+		"value validator not applied": {
+			ns:    lowercase,
+			input: "vibe:user:ABC",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := tt.ns.ParseID(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseID(%q) error = %v, want error containing %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseID(%q) unexpected error = %v", tt.input, err)
+			}
+			if id.String() != tt.input {
+				t.Errorf("ParseID() = %q, want %q", id.String(), tt.input)
+			}
+		})
+	}
+}
+
+func TestNamespace_Prefix(t *testing.T) {
+	ns := NewNamespace("prd")
+
+	prefix, err := ns.Prefix(Type("user"))
+	if err != nil {
+		t.Fatalf("Prefix() unexpected error = %v", err)
+	}
+	if prefix != "vibe:user:" {
+		t.Errorf("Prefix() = %q, want %q", prefix, "vibe:user:")
+	}
+
+	keys := map[string]bool{
+		"vibe:user:123":     true,
+		"vibe:username:123": false,
+		"vibe:order:123":    false,
+		"dev:user:123":      false,
+	}
+	for key, want := range keys {
+		if got := strings.HasPrefix(key, prefix); got != want {
+			t.Errorf("strings.HasPrefix(%q, %q) = %v, want %v", key, prefix, got, want)
+		}
+
+		id, err := ParseID(key)
+		if err != nil {
+			t.Fatalf("ParseID(%q) unexpected error = %v", key, err)
+		}
+		if got := id.HasPrefix("vibe", Type("user")); got != want {
+			t.Errorf("ParseID(%q).HasPrefix(\"vibe\", \"user\") = %v, want %v", key, got, want)
+		}
+	}
+
+	if _, err := ns.Prefix(Type("bad:type")); err == nil {
+		t.Error("Prefix() with invalid type expected error but got nil")
+	}
+}
+
+func TestNamespace_NewIDs(t *testing.T) {
+	ns := NewNamespace("dev")
+
+	t.Run("requested length without duplicates", func(t *testing.T) {
+		ids, err := ns.NewIDs(Type("user"), 500)
+		if err != nil {
+			t.Fatalf("NewIDs() unexpected error = %v", err)
+		}
+		if len(ids) != 500 {
+			t.Fatalf("NewIDs() returned %d IDs, want 500", len(ids))
+		}
+
+		seen := make(map[ID]bool, len(ids))
+		for _, id := range ids {
+			if seen[id] {
+				t.Errorf("NewIDs() returned duplicate ID %s", id)
+			}
+			seen[id] = true
+
+			if id.Env() != "dev" || id.Type() != Type("user") {
+				t.Errorf("NewIDs() returned %s, want env dev and type user", id)
+			}
+		}
+	})
+
+	t.Run("zero count", func(t *testing.T) {
+		ids, err := ns.NewIDs(Type("user"), 0)
+		if err != nil || ids != nil {
+			t.Errorf("NewIDs(0) = %v, %v, want nil, nil", ids, err)
+		}
+	})
+
+	t.Run("negative count", func(t *testing.T) {
+		if _, err := ns.NewIDs(Type("user"), -1); err == nil {
+			t.Error("NewIDs(-1) expected error but got nil")
+		}
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		if _, err := ns.NewIDs(Type("123bad"), 3); err == nil {
+			t.Error("NewIDs() with invalid type expected error but got nil")
+		}
+	})
+
+	t.Run("repeating generator", func(t *testing.T) {
+		fixed := ns.WithGenerator(func() string { return "same" })
+		if _, err := fixed.NewIDs(Type("user"), 2); err == nil {
+			t.Error("NewIDs() with repeating generator expected error but got nil")
+		}
+	})
+}
+
+func TestNamespace_Factory(t *testing.T) {
+	t.Run("produces unique valid IDs", func(t *testing.T) {
+		ns := NewNamespace("dev")
+		newUser, err := ns.Factory(Type("user"))
+		if err != nil {
+			t.Fatalf("Factory() unexpected error = %v", err)
 		}
-		ids[id.Value()] = true
+
+		seen := make(map[ID]bool)
+		for i := 0; i < 100; i++ {
+			id, err := newUser()
+			if err != nil {
+				t.Fatalf("factory() unexpected error = %v", err)
+			}
+			if err := id.Validate(); err != nil {
+				t.Errorf("factory() produced invalid ID %v: %v", id, err)
+			}
+			if id.Env() != "dev" || id.Type() != Type("user") {
+				t.Errorf("factory() = %v, want dev:user:...", id)
+			}
+			if seen[id] {
+				t.Errorf("factory() produced duplicate ID %v", id)
+			}
+			seen[id] = true
+		}
+	})
+
+	t.Run("invalid type errors at creation", func(t *testing.T) {
+		newBad, err := NewNamespace("dev").Factory(Type("1user"))
+		if err == nil {
+			t.Fatal("Factory() expected error but got nil")
+		}
+		if newBad != nil {
+			t.Error("Factory() returned a function alongside an error")
+		}
+		if !strings.Contains(err.Error(), "invalid object type: type must start with a letter") {
+			t.Errorf("Factory() error = %v, want invalid type error", err)
+		}
+	})
+
+	t.Run("unregistered type errors at creation in strict mode", func(t *testing.T) {
+		if _, err := NewNamespace("dev").WithStrictTypes(true).Factory(Type("user")); err == nil {
+			t.Error("Factory() expected error but got nil")
+		}
+	})
+}
+
+func TestNamespace_NewIDWithTimestamp(t *testing.T) {
+	ns := NewNamespace("dev")
+	created := time.Date(2019, time.March, 14, 15, 9, 26, 535000000, time.UTC)
+
+	a, err := ns.NewIDWithTimestamp(Type("order"), created)
+	if err != nil {
+		t.Fatalf("NewIDWithTimestamp() unexpected error = %v", err)
+	}
+	b, err := ns.NewIDWithTimestamp(Type("order"), created)
+	if err != nil {
+		t.Fatalf("NewIDWithTimestamp() unexpected error = %v", err)
 	}
+
+	if a == b {
+		t.Errorf("NewIDWithTimestamp() generated duplicate IDs: %v", a)
+	}
+
+	for _, id := range []ID{a, b} {
+		k, err := ksuid.Parse(id.ObjectID())
+		if err != nil {
+			t.Fatalf("ksuid.Parse(%q) unexpected error = %v", id.ObjectID(), err)
+		}
+		if !k.Time().Equal(created.Truncate(time.Second)) {
+			t.Errorf("embedded time = %v, want %v", k.Time().UTC(), created.Truncate(time.Second))
+		}
+	}
+
+	t.Run("invalid type", func(t *testing.T) {
+		if _, err := ns.NewIDWithTimestamp(Type("1order"), created); err == nil {
+			t.Error("NewIDWithTimestamp() expected error but got nil")
+		}
+	})
+
+	t.Run("time out of range", func(t *testing.T) {
+		if _, err := ns.NewIDWithTimestamp(Type("order"), time.Unix(0, 0)); err == nil {
+			t.Error("NewIDWithTimestamp() expected error but got nil")
+		}
+	})
 }
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// TypeRegistry records a set of known object types, so that typos such as
+// Type("usr") can be rejected instead of silently creating a new type.
+// Every Namespace carries a registry; use WithTypeRegistry to share one across
+// namespaces and enforce it. It is safe for concurrent use.
+type TypeRegistry struct {
+	mu      sync.RWMutex
+	types   []Type
+	known   map[Type]bool
+	formats map[Type]*regexp.Regexp
+}
+
+// NewTypeRegistry creates a registry containing the given types.
+func NewTypeRegistry(types ...Type) *TypeRegistry {
+	r := &TypeRegistry{
+		known:   make(map[Type]bool),
+		formats: make(map[Type]*regexp.Regexp),
+	}
+	r.Register(types...)
+
+	return r
+}
+
+// Register adds the types to the registry. Registering a type more than once has
+// no effect. Types are not validated here; Parse and Namespace still validate them.
+func (r *TypeRegistry) Register(types ...Type) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range types {
+		r.add(t)
+	}
+}
+
+// Has reports whether t is registered.
+func (r *TypeRegistry) Has(t Type) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.known[t]
+}
+
+// Types returns the registered types in registration order, or nil if there are none.
+func (r *TypeRegistry) Types() []Type {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.types) == 0 {
+		return nil
+	}
+	return append([]Type(nil), r.types...)
+}
+
+// Parse is like ParseType but also rejects syntactically valid types that are not
+// registered. Syntax errors are returned unchanged from ParseType.
+func (r *TypeRegistry) Parse(s string) (Type, error) {
+	t, err := ParseType(s)
+	if err != nil {
+		return "", err
+	}
+
+	if !r.Has(t) {
+		return "", fmt.Errorf("type %q is not registered", t)
+	}
+
+	return t, nil
+}
+
+func (r *TypeRegistry) registerFormat(t Type, re *regexp.Regexp) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.add(t)
+	r.formats[t] = re
+}
+
+// add records t as known. Callers must hold r.mu.
+func (r *TypeRegistry) add(t Type) {
+	if r.known[t] {
+		return
+	}
+	r.known[t] = true
+	r.types = append(r.types, t)
+}
+
+func (r *TypeRegistry) format(t Type) *regexp.Regexp {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.formats[t]
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTypeRegistry_Parse(t *testing.T) {
+	registry := NewTypeRegistry(Type("user"), Type("order"))
+
+	_, syntaxErr := ParseType("123user")
+	if syntaxErr == nil {
+		t.Fatal("ParseType(\"123user\") expected error but got nil")
+	}
+
+	tests := map[string]struct {
+		input    string
+		expected Type
+		wantErr  string
+	}{
+		"registered type": {
+			input:    "user",
+			expected: Type("user"),
+		},
+		"unregistered valid type": {
+			input:   "usr",
+			wantErr: `type "usr" is not registered`,
+		},
+		"syntactically invalid type": {
+			input:   "123user",
+			wantErr: syntaxErr.Error(),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := registry.Parse(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Parse(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error = %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("Parse(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTypeRegistry_Register(t *testing.T) {
+	registry := NewTypeRegistry()
+	if types := registry.Types(); types != nil {
+		t.Errorf("Types() on empty registry = %v, want nil", types)
+	}
+
+	registry.Register(Type("user"), Type("order"), Type("user"))
+	want := []Type{"user", "order"}
+	if types := registry.Types(); !reflect.DeepEqual(types, want) {
+		t.Errorf("Types() = %v, want %v", types, want)
+	}
+	if !registry.Has(Type("order")) || registry.Has(Type("invoice")) {
+		t.Errorf("Has() returned wrong result for %v", registry.Types())
+	}
+}
+
+func TestNamespace_WithTypeRegistry(t *testing.T) {
+	registry := NewTypeRegistry(Type("user"))
+	dev := NewNamespace("dev").WithTypeRegistry(registry)
+	staging := NewNamespace("staging").WithTypeRegistry(registry)
+
+	if _, err := dev.NewID(Type("user")); err != nil {
+		t.Errorf("NewID() with registered type unexpected error = %v", err)
+	}
+	if _, err := dev.NewID(Type("usr")); err == nil {
+		t.Error("NewID() with unregistered type expected error but got nil")
+	}
+	if _, err := dev.NewIDWithValue(Type("usr"), "123"); err == nil {
+		t.Error("NewIDWithValue() with unregistered type expected error but got nil")
+	}
+
+	registry.Register(Type("order"))
+	if _, err := staging.NewIDWithValue(Type("order"), "123"); err != nil {
+		t.Errorf("NewIDWithValue() with type registered later unexpected error = %v", err)
+	}
+
+	if _, err := NewNamespace("dev").NewID(Type("usr")); err != nil {
+		t.Errorf("NewID() without a registry unexpected error = %v", err)
+	}
+	if _, err := dev.WithTypeRegistry(nil).NewID(Type("usr")); err != nil {
+		t.Errorf("NewID() after WithTypeRegistry(nil) unexpected error = %v", err)
+	}
+}
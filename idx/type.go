@@ -21,16 +21,30 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 // Type represents an object type identifier used in IDs.
-// Types must start with a letter and contain only letters, numbers, and underscores.
-// Maximum length is 32 characters.
+// Types must start with a letter and contain only letters, numbers, and underscores
+// (and hyphens if AllowTypeHyphens is set).
+// Maximum length is MaxTypeLength characters.
 type Type string
 
+// MaxTypeLength is the maximum number of characters allowed in a Type.
+// It defaults to 32 and should only be changed during program initialization.
+var MaxTypeLength = 32
+
+// AllowTypeHyphens permits hyphens after the first character of a Type
+// (e.g. "order-item"). It defaults to false and should only be changed during
+// program initialization.
+var AllowTypeHyphens = false
+
 // typeRegex validates that types start with a letter and contain only alphanumeric characters and underscores.
 var typeRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
 
+// hyphenTypeRegex is typeRegex with hyphens allowed after the first character.
+var hyphenTypeRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
 // String returns the string representation of the Type.
 func (t Type) String() string {
 	return string(t)
@@ -38,25 +52,45 @@ func (t Type) String() string {
 
 // Validate checks if the Type meets all requirements:
 // - Not empty
-// - Maximum 32 characters
+// - Maximum MaxTypeLength characters
 // - No colons (to avoid conflicts with ID format)
 // - Must start with letter and contain only letters, numbers, and underscores
+// (and hyphens if AllowTypeHyphens is set)
+//
+// Results are cached per type and configuration, so repeated validation of the
+// same type in a hot loop skips the regex match.
 func (t Type) Validate() error {
+	key := typeCacheKey{t: t, maxLength: MaxTypeLength, allowHyphens: AllowTypeHyphens}
+	if err, ok := typeValidationCache.get(key); ok {
+		return err
+	}
+
+	err := t.validate()
+	typeValidationCache.put(key, err)
+	return err
+}
+
+// validate performs the uncached checks for Validate.
+func (t Type) validate() error {
 	str := string(t)
 
 	if str == "" {
 		return fmt.Errorf("type cannot be empty")
 	}
 
-	if len(str) > 32 {
-		return fmt.Errorf("type cannot be longer than 32 characters")
+	if len(str) > MaxTypeLength {
+		return fmt.Errorf("type cannot be longer than %d characters", MaxTypeLength)
 	}
 
 	if strings.Contains(str, ":") {
 		return fmt.Errorf("type cannot contain colons")
 	}
 
-	if !typeRegex.MatchString(str) {
+	if AllowTypeHyphens {
+		if !hyphenTypeRegex.MatchString(str) {
+			return fmt.Errorf("type must start with a letter and contain only letters, numbers, underscores, and hyphens")
+		}
+	} else if !typeRegex.MatchString(str) {
 		return fmt.Errorf("type must start with a letter and contain only letters, numbers, and underscores")
 	}
 
@@ -72,3 +106,50 @@ func ParseType(s string) (Type, error) {
 	}
 	return t, nil
 }
+
+// MustParseType is like ParseType but panics if the string is not a valid Type.
+// It is intended for package-level variables and tests initialized from constant
+// strings, like regexp.MustCompile, and should not be used on request input.
+func MustParseType(s string) Type {
+	t, err := ParseType(s)
+	if err != nil {
+		panic(fmt.Errorf("idx: MustParseType(%q): %w", s, err))
+	}
+
+	return t
+}
+
+// maxTypeCacheEntries bounds the validation cache so that validating untrusted
+// input cannot grow it without limit. Once full, new types are validated uncached.
+const maxTypeCacheEntries = 1024
+
+// typeCacheKey includes the configuration so that changing MaxTypeLength or
+// AllowTypeHyphens never returns a stale result.
+type typeCacheKey struct {
+	t            Type
+	maxLength    int
+	allowHyphens bool
+}
+
+// typeCache remembers Validate results. It is safe for concurrent use.
+type typeCache struct {
+	mu      sync.RWMutex
+	results map[typeCacheKey]error
+}
+
+var typeValidationCache = &typeCache{results: make(map[typeCacheKey]error)}
+
+func (c *typeCache) get(key typeCacheKey) (error, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	err, ok := c.results[key]
+	return err, ok
+}
+
+func (c *typeCache) put(key typeCacheKey, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.results) < maxTypeCacheEntries {
+		c.results[key] = err
+	}
+}
@@ -1,6 +1,7 @@
 package idx
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -197,3 +198,101 @@ func TestParseType(t *testing.T) {
 		})
 	}
 }
+
+func TestMustParseType(t *testing.T) {
+	if got := MustParseType("user"); got != Type("user") {
+		t.Errorf("MustParseType() = %q, want %q", got, "user")
+	}
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok {
+			t.Fatalf("MustParseType() panic = %v, want error", r)
+		}
+		if want := `idx: MustParseType("user:admin"): `; !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("MustParseType() panic = %q, want prefix %q", err, want)
+		}
+		_, parseErr := ParseType("user:admin")
+		if unwrapped := errors.Unwrap(err); unwrapped == nil || unwrapped.Error() != parseErr.Error() {
+			t.Errorf("MustParseType() panic wraps %v, want %v", unwrapped, parseErr)
+		}
+	}()
+	MustParseType("user:admin")
+}
+
+func TestType_Validate_Configuration(t *testing.T) {
+	// Restore the package defaults after the test
+	defer func(maxLen int, hyphens bool) {
+		MaxTypeLength = maxLen
+		AllowTypeHyphens = hyphens
+	}(MaxTypeLength, AllowTypeHyphens)
+
+	long := Type(strings.Repeat("a", 40))
+
+	t.Run("defaults stay strict", func(t *testing.T) {
+		if err := long.Validate(); err == nil {
+			t.Error("Validate() of 40-character type expected error but got nil")
+		}
+		if err := Type("order-item").Validate(); err == nil {
+			t.Error("Validate() of hyphenated type expected error but got nil")
+		}
+	})
+
+	t.Run("raised length limit", func(t *testing.T) {
+		MaxTypeLength = 64
+		defer func() { MaxTypeLength = 32 }()
+
+		if err := long.Validate(); err != nil {
+			t.Errorf("Validate() unexpected error = %v", err)
+		}
+		err := Type(strings.Repeat("a", 65)).Validate()
+		if err == nil || err.Error() != "type cannot be longer than 64 characters" {
+			t.Errorf("Validate() error = %v, want length error mentioning 64", err)
+		}
+	})
+
+	t.Run("hyphens enabled", func(t *testing.T) {
+		AllowTypeHyphens = true
+		defer func() { AllowTypeHyphens = false }()
+
+		if err := Type("order-item").Validate(); err != nil {
+			t.Errorf("Validate() unexpected error = %v", err)
+		}
+		if err := Type("-order").Validate(); err == nil {
+			t.Error("Validate() of type starting with hyphen expected error but got nil")
+		}
+	})
+}
+
+func TestType_Validate_Cached(t *testing.T) {
+	types := []Type{"user", "order_item", "", "1user", "user:admin", "order-item", Type(strings.Repeat("a", 40))}
+
+	for _, typ := range types {
+		fresh := typ.validate()
+
+		// The first call fills the cache and the second is served from it
+		for range 2 {
+			err := typ.Validate()
+			if (err == nil) != (fresh == nil) || (err != nil && err.Error() != fresh.Error()) {
+				t.Errorf("Validate(%q) = %v, want %v", typ, err, fresh)
+			}
+		}
+	}
+}
+
+func BenchmarkType_Validate(b *testing.B) {
+	typ := Type("subscription_item")
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = typ.validate()
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = typ.Validate()
+		}
+	})
+}
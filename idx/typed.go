@@ -0,0 +1,112 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import "fmt"
+
+// TypeTag names the object type bound to a TypedID. It is usually implemented by
+// an empty struct declared once per entity type:
+//
+//	type UserTag struct{}
+//
+//	func (UserTag) Type() idx.Type { return "user" }
+//
+//	type UserID = idx.TypedID[UserTag]
+type TypeTag interface {
+	Type() Type
+}
+
+// TypedID is an ID whose object type is fixed at compile time by the tag T, so
+// that, for example, a TypedID[UserTag] cannot be passed where a TypedID[OrderTag]
+// is expected. The plain ID is embedded and available as the ID field.
+// Construct TypedIDs with NewTypedID, AsTypedID or ParseTypedID, which check
+// that the embedded ID's type matches the tag.
+type TypedID[T TypeTag] struct {
+	ID
+}
+
+// NewTypedID creates a new TypedID in the namespace with an auto-generated value,
+// using the object type named by T.
+func NewTypedID[T TypeTag](n Namespace) (TypedID[T], error) {
+	var tag T
+	id, err := n.NewID(tag.Type())
+	if err != nil {
+		return TypedID[T]{}, err
+	}
+
+	return TypedID[T]{ID: id}, nil
+}
+
+// AsTypedID converts a plain ID into a TypedID. Returns an error if the ID is
+// invalid or its object type does not match the type named by T.
+func AsTypedID[T TypeTag](id ID) (TypedID[T], error) {
+	if err := id.Validate(); err != nil {
+		return TypedID[T]{}, err
+	}
+
+	var tag T
+	if id.objectType != tag.Type() {
+		return TypedID[T]{}, fmt.Errorf("object type mismatch: expected %q, got %q", tag.Type(), id.objectType)
+	}
+
+	return TypedID[T]{ID: id}, nil
+}
+
+// ParseTypedID parses a string with ParseID and converts it with AsTypedID.
+func ParseTypedID[T TypeTag](s string) (TypedID[T], error) {
+	id, err := ParseID(s)
+	if err != nil {
+		return TypedID[T]{}, err
+	}
+
+	return AsTypedID[T](id)
+}
+
+// UnmarshalJSON implements json.Unmarshaler like ID.UnmarshalJSON, and also
+// rejects IDs whose object type does not match the type named by T. A JSON null
+// leaves the zero TypedID.
+func (t *TypedID[T]) UnmarshalJSON(data []byte) error {
+	return t.decode(func(id *ID) error { return id.UnmarshalJSON(data) })
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler like ID.UnmarshalText, and
+// also rejects IDs whose object type does not match the type named by T.
+func (t *TypedID[T]) UnmarshalText(text []byte) error {
+	return t.decode(func(id *ID) error { return id.UnmarshalText(text) })
+}
+
+// GobDecode implements gob.GobDecoder like ID.GobDecode, and also rejects IDs
+// whose object type does not match the type named by T.
+func (t *TypedID[T]) GobDecode(data []byte) error {
+	return t.decode(func(id *ID) error { return id.GobDecode(data) })
+}
+
+// Scan implements sql.Scanner like ID.Scan, and also rejects IDs whose object
+// type does not match the type named by T. A NULL value leaves the zero TypedID.
+func (t *TypedID[T]) Scan(src any) error {
+	return t.decode(func(id *ID) error { return id.Scan(src) })
+}
+
+// decode fills a plain ID with the embedded ID's decoder and converts it with
+// AsTypedID, so the decoders promoted from ID cannot bypass the tag check.
+// The zero ID, decoded from null or empty input, is accepted as is.
+func (t *TypedID[T]) decode(decodeID func(*ID) error) error {
+	var id ID
+	if err := decodeID(&id); err != nil {
+		return err
+	}
+
+	if id.IsZero() {
+		*t = TypedID[T]{}
+		return nil
+	}
+
+	typed, err := AsTypedID[T](id)
+	if err != nil {
+		return err
+	}
+
+	*t = typed
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package idx
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type userTag struct{}
+
+func (userTag) Type() Type { return "user" }
+
+type orderTag struct{}
+
+func (orderTag) Type() Type { return "order" }
+
+type (
+	userID  = TypedID[userTag]
+	orderID = TypedID[orderTag]
+)
+
+func TestNewTypedID(t *testing.T) {
+	ns := NewNamespace("dev")
+
+	id, err := NewTypedID[userTag](ns)
+	if err != nil {
+		t.Fatalf("NewTypedID() unexpected error = %v", err)
+	}
+
+	if id.Type() != Type("user") {
+		t.Errorf("NewTypedID().Type() = %q, want %q", id.Type(), "user")
+	}
+	if id.Env() != "dev" {
+		t.Errorf("NewTypedID().Env() = %q, want %q", id.Env(), "dev")
+	}
+}
+
+func TestAsTypedID(t *testing.T) {
+	plain, err := ParseID("dev:user:123")
+	if err != nil {
+		t.Fatalf("ParseID() unexpected error = %v", err)
+	}
+
+	t.Run("matching tag round-trips", func(t *testing.T) {
+		var typed userID
+		typed, err := AsTypedID[userTag](plain)
+		if err != nil {
+			t.Fatalf("AsTypedID() unexpected error = %v", err)
+		}
+		if typed.ID != plain {
+			t.Errorf("AsTypedID().ID = %v, want %v", typed.ID, plain)
+		}
+	})
+
+	t.Run("mismatched tag errors", func(t *testing.T) {
+		_, err := AsTypedID[orderTag](plain)
+		if err == nil {
+			t.Fatal("AsTypedID() expected error but got nil")
+		}
+		if !strings.Contains(err.Error(), `object type mismatch: expected "order", got "user"`) {
+			t.Errorf("AsTypedID() error = %v, want type mismatch", err)
+		}
+	})
+
+	t.Run("invalid ID errors", func(t *testing.T) {
+		if _, err := AsTypedID[userTag](ID{}); err == nil {
+			t.Error("AsTypedID() expected error but got nil")
+		}
+	})
+}
+
+func TestParseTypedID(t *testing.T) {
+	var typed orderID
+	typed, err := ParseTypedID[orderTag]("vibe:order:ord_1")
+	if err != nil {
+		t.Fatalf("ParseTypedID() unexpected error = %v", err)
+	}
+	if typed.String() != "vibe:order:ord_1" {
+		t.Errorf("ParseTypedID().String() = %q, want %q", typed.String(), "vibe:order:ord_1")
+	}
+
+	if _, err := ParseTypedID[orderTag]("vibe:user:u_1"); err == nil {
+		t.Error("ParseTypedID() with wrong type expected error but got nil")
+	}
+	if _, err := ParseTypedID[orderTag]("invalid"); err == nil {
+		t.Error("ParseTypedID() with malformed input expected error but got nil")
+	}
+}
+
+func TestTypedID_Decoding(t *testing.T) {
+	decoders := map[string]func(*userID, string) error{
+		"json": func(u *userID, s string) error { return json.Unmarshal([]byte(`"`+s+`"`), u) },
+		"text": func(u *userID, s string) error { return u.UnmarshalText([]byte(s)) },
+		"scan": func(u *userID, s string) error { return u.Scan(s) },
+		"gob": func(u *userID, s string) error {
+			id, err := ParseID(s)
+			if err != nil {
+				return err
+			}
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(id); err != nil {
+				return err
+			}
+			return gob.NewDecoder(&buf).Decode(u)
+		},
+	}
+
+	for name, decode := range decoders {
+		t.Run(name, func(t *testing.T) {
+			var u userID
+			if err := decode(&u, "vibe:user:123"); err != nil {
+				t.Fatalf("decode() unexpected error = %v", err)
+			}
+			if u.String() != "vibe:user:123" {
+				t.Errorf("decode() = %q, want %q", u.String(), "vibe:user:123")
+			}
+
+			wrong := u
+			err := decode(&wrong, "vibe:order:123")
+			if err == nil || !strings.Contains(err.Error(), "object type mismatch") {
+				t.Errorf("decode() with wrong type error = %v, want object type mismatch", err)
+			}
+			if wrong != u {
+				t.Errorf("decode() with wrong type changed the ID to %v", wrong)
+			}
+		})
+	}
+
+	t.Run("null leaves zero", func(t *testing.T) {
+		u, err := ParseTypedID[userTag]("vibe:user:123")
+		if err != nil {
+			t.Fatalf("ParseTypedID() unexpected error = %v", err)
+		}
+		if err := json.Unmarshal([]byte("null"), &u); err != nil {
+			t.Fatalf("json.Unmarshal(null) unexpected error = %v", err)
+		}
+		if !u.IsZero() {
+			t.Errorf("json.Unmarshal(null) = %v, want zero ID", u)
+		}
+		if err := u.Scan(nil); err != nil || !u.IsZero() {
+			t.Errorf("Scan(nil) = %v, %v, want zero ID and nil", u, err)
+		}
+	})
+
+	t.Run("struct field", func(t *testing.T) {
+		var payload struct {
+			Owner userID `json:"owner"`
+		}
+		if err := json.Unmarshal([]byte(`{"owner":"vibe:order:9"}`), &payload); err == nil {
+			t.Errorf("json.Unmarshal() with wrong type = %v, want error", payload.Owner)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"sync"
+	"time"
+)
+
+// Batcher accumulates items and hands them to a flush callback in bulk, either
+// when the batch reaches its maximum size or when the oldest pending item
+// reaches its maximum age. It is safe for concurrent use.
+type Batcher[T any] struct {
+	flushFunc func([]T)
+	maxSize   int
+	maxAge    time.Duration
+
+	mu    sync.Mutex
+	items []T
+	timer *time.Timer
+	gen   int // incremented each time a batch is taken, to ignore stale timers and order deliveries
+
+	flushMu   sync.Mutex // serializes calls to flushFunc
+	turn      *sync.Cond // signalled on flushMu when delivered advances
+	delivered int        // gen of the last batch delivered, guarded by flushMu
+}
+
+// NewBatcher creates a new Batcher that delivers batches to flush.
+// Defaults to a maximum size of 100 items and no age limit.
+// Returns a batcher that can be configured with fluent methods before use.
+func NewBatcher[T any](flush func([]T)) *Batcher[T] {
+	b := &Batcher[T]{
+		flushFunc: flush,
+		maxSize:   100, // Default batch size
+	}
+	b.turn = sync.NewCond(&b.flushMu)
+	return b
+}
+
+// WithMaxSize sets the number of items that triggers a flush.
+// A value of 0 or less disables size-triggered flushing.
+func (b *Batcher[T]) WithMaxSize(n int) *Batcher[T] {
+	b.maxSize = n
+	return b
+}
+
+// WithMaxAge sets how long the oldest pending item may wait before the batch is
+// flushed. A value of 0 or less disables age-triggered flushing.
+func (b *Batcher[T]) WithMaxAge(d time.Duration) *Batcher[T] {
+	b.maxAge = d
+	return b
+}
+
+// Add appends an item to the current batch, flushing it synchronously if the
+// maximum size is reached. Age-triggered flushes run on a timer goroutine.
+func (b *Batcher[T]) Add(item T) {
+	b.mu.Lock()
+	b.items = append(b.items, item)
+
+	if b.maxSize > 0 && len(b.items) >= b.maxSize {
+		batch, seq := b.take()
+		b.mu.Unlock()
+		b.deliver(batch, seq)
+		return
+	}
+
+	if len(b.items) == 1 && b.maxAge > 0 {
+		gen := b.gen
+		b.timer = time.AfterFunc(b.maxAge, func() { b.flushGen(gen) })
+	}
+	b.mu.Unlock()
+}
+
+// Flush immediately delivers any pending items, even if neither threshold has
+// been reached. It also waits for batches taken earlier, such as one being
+// flushed by the age timer, to be delivered, so every item added before Flush
+// was called has reached the callback when it returns.
+func (b *Batcher[T]) Flush() {
+	b.mu.Lock()
+	batch, seq := b.take()
+	b.mu.Unlock()
+
+	b.deliver(batch, seq)
+}
+
+// flushGen flushes the batch only if it is still the batch the timer was
+// started for.
+func (b *Batcher[T]) flushGen(gen int) {
+	b.mu.Lock()
+	if gen != b.gen {
+		b.mu.Unlock()
+		return
+	}
+	batch, seq := b.take()
+	b.mu.Unlock()
+
+	b.deliver(batch, seq)
+}
+
+// take removes and returns the pending items along with the sequence number that
+// orders their delivery. Callers must hold b.mu.
+func (b *Batcher[T]) take() ([]T, int) {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.gen++
+
+	batch := b.items
+	b.items = nil
+	return batch, b.gen
+}
+
+// deliver passes a non-empty batch to the flush callback, one call at a time and
+// in the order the batches were taken. Every taken batch must be delivered, even
+// an empty one, so later batches are not held back.
+func (b *Batcher[T]) deliver(batch []T, seq int) {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	for b.delivered != seq-1 {
+		b.turn.Wait()
+	}
+	defer func() {
+		b.delivered = seq
+		b.turn.Broadcast()
+	}()
+
+	if len(batch) > 0 {
+		b.flushFunc(batch)
+	}
+}
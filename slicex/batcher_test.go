@@ -0,0 +1,175 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchRecorder collects flushed batches for assertions.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (r *batchRecorder) flush(batch []int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, batch)
+}
+
+func (r *batchRecorder) get() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]int(nil), r.batches...)
+}
+
+func TestBatcher(t *testing.T) {
+	t.Run("size-triggered flush", func(t *testing.T) {
+		rec := &batchRecorder{}
+		b := NewBatcher(rec.flush).WithMaxSize(3)
+
+		for i := 1; i <= 7; i++ {
+			b.Add(i)
+		}
+
+		expected := [][]int{{1, 2, 3}, {4, 5, 6}}
+		if !reflect.DeepEqual(rec.get(), expected) {
+			t.Errorf("Expected batches %v, got %v", expected, rec.get())
+		}
+	})
+
+	t.Run("age-triggered flush", func(t *testing.T) {
+		rec := &batchRecorder{}
+		b := NewBatcher(rec.flush).
+			WithMaxSize(100).
+			WithMaxAge(20 * time.Millisecond)
+
+		b.Add(1)
+		b.Add(2)
+
+		if len(rec.get()) != 0 {
+			t.Fatalf("Expected no flush before max age, got %v", rec.get())
+		}
+
+		time.Sleep(60 * time.Millisecond)
+
+		expected := [][]int{{1, 2}}
+		if !reflect.DeepEqual(rec.get(), expected) {
+			t.Errorf("Expected batches %v, got %v", expected, rec.get())
+		}
+	})
+
+	t.Run("manual flush of partial batch", func(t *testing.T) {
+		rec := &batchRecorder{}
+		b := NewBatcher(rec.flush).WithMaxSize(10)
+
+		b.Add(1)
+		b.Add(2)
+		b.Flush()
+		b.Flush() // empty batches are not delivered
+
+		expected := [][]int{{1, 2}}
+		if !reflect.DeepEqual(rec.get(), expected) {
+			t.Errorf("Expected batches %v, got %v", expected, rec.get())
+		}
+	})
+
+	t.Run("concurrent adds deliver every item", func(t *testing.T) {
+		rec := &batchRecorder{}
+		b := NewBatcher(rec.flush).
+			WithMaxSize(7).
+			WithMaxAge(5 * time.Millisecond)
+
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					b.Add(i)
+				}
+			}()
+		}
+		wg.Wait()
+		b.Flush()
+
+		total := 0
+		for _, batch := range rec.get() {
+			total += len(batch)
+		}
+		if total != 200 {
+			t.Errorf("Expected 200 flushed items, got %d", total)
+		}
+	})
+
+	t.Run("flush waits for an in-flight timer delivery", func(t *testing.T) {
+		entered := make(chan struct{})
+		release := make(chan struct{})
+		var delivered []int
+		b := NewBatcher(func(batch []int) {
+			close(entered)
+			<-release
+			delivered = batch
+		}).WithMaxAge(time.Millisecond)
+
+		b.Add(1)
+		<-entered // the timer has taken the batch and is delivering it
+
+		flushed := make(chan struct{})
+		go func() {
+			b.Flush()
+			close(flushed)
+		}()
+
+		select {
+		case <-flushed:
+			t.Fatal("Flush returned before the in-flight timer delivery completed")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		close(release)
+		<-flushed
+		if !reflect.DeepEqual(delivered, []int{1}) {
+			t.Errorf("Expected batch [1] delivered, got %v", delivered)
+		}
+	})
+
+	t.Run("timer and manual flushes deliver in add order", func(t *testing.T) {
+		var mu sync.Mutex
+		var got []int
+		b := NewBatcher(func(batch []int) {
+			// A slow callback widens the window for a timer flush to race Flush
+			time.Sleep(50 * time.Microsecond)
+			mu.Lock()
+			got = append(got, batch...)
+			mu.Unlock()
+		}).
+			WithMaxSize(5).
+			WithMaxAge(20 * time.Microsecond)
+
+		var expected []int
+		for i := 0; i < 300; i++ {
+			b.Add(i)
+			expected = append(expected, i)
+			if i%7 == 0 {
+				time.Sleep(30 * time.Microsecond)
+			}
+			if i%11 == 0 {
+				b.Flush()
+			}
+		}
+		b.Flush()
+
+		// Flush waits for in-flight timer deliveries, so everything is visible now
+		mu.Lock()
+		defer mu.Unlock()
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Expected items delivered in add order %v, got %v", expected, got)
+		}
+	})
+}
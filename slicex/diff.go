@@ -0,0 +1,88 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+// EditOp is the kind of operation in an edit script produced by EditDistance.
+type EditOp int
+
+const (
+	// EditKeep leaves an element that is present in both inputs.
+	EditKeep EditOp = iota
+	// EditInsert adds an element that is only present in the target.
+	EditInsert
+	// EditDelete removes an element that is only present in the source.
+	EditDelete
+)
+
+// String returns the lowercase name of the operation.
+func (op EditOp) String() string {
+	switch op {
+	case EditKeep:
+		return "keep"
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// Edit is a single step of an edit script: keep, insert or delete Value.
+type Edit[T any] struct {
+	Op    EditOp
+	Value T
+}
+
+// EditDistance returns a minimal edit script that transforms a into b, based on
+// the longest common subsequence of the two slices. Applying the script in order,
+// keeping and deleting elements of a and inserting elements of b, reproduces b.
+// Within a changed region deletions are listed before insertions.
+// The script has len(a)+len(b)-k entries for a common subsequence of length k;
+// time and memory are O(len(a)*len(b)). Returns nil if both slices are empty.
+func EditDistance[T comparable](a, b []T) []Edit[T] {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]Edit[T], 0, len(a)+len(b)-lcs[0][0])
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, Edit[T]{Op: EditKeep, Value: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, Edit[T]{Op: EditDelete, Value: a[i]})
+			i++
+		default:
+			edits = append(edits, Edit[T]{Op: EditInsert, Value: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, Edit[T]{Op: EditDelete, Value: a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, Edit[T]{Op: EditInsert, Value: b[j]})
+	}
+
+	return edits
+}
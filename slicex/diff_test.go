@@ -0,0 +1,80 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	keep := func(s string) Edit[string] { return Edit[string]{Op: EditKeep, Value: s} }
+	ins := func(s string) Edit[string] { return Edit[string]{Op: EditInsert, Value: s} }
+	del := func(s string) Edit[string] { return Edit[string]{Op: EditDelete, Value: s} }
+
+	tests := map[string]struct {
+		a        []string
+		b        []string
+		expected []Edit[string]
+	}{
+		"identical": {
+			a:        []string{"a", "b", "c"},
+			b:        []string{"a", "b", "c"},
+			expected: []Edit[string]{keep("a"), keep("b"), keep("c")},
+		},
+		"pure insertion": {
+			a:        []string{"a", "c"},
+			b:        []string{"a", "b", "c", "d"},
+			expected: []Edit[string]{keep("a"), ins("b"), keep("c"), ins("d")},
+		},
+		"pure deletion": {
+			a:        []string{"a", "b", "c", "d"},
+			b:        []string{"b", "d"},
+			expected: []Edit[string]{del("a"), keep("b"), del("c"), keep("d")},
+		},
+		"mixed change": {
+			a:        []string{"a", "b", "c", "d"},
+			b:        []string{"a", "x", "c", "d", "e"},
+			expected: []Edit[string]{keep("a"), del("b"), ins("x"), keep("c"), keep("d"), ins("e")},
+		},
+		"from empty": {
+			a:        nil,
+			b:        []string{"a", "b"},
+			expected: []Edit[string]{ins("a"), ins("b")},
+		},
+		"both empty": {
+			a:        []string{},
+			b:        nil,
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := EditDistance(tt.a, tt.b)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("EditDistance(%v, %v) = %v, expected %v", tt.a, tt.b, result, tt.expected)
+			}
+
+			// Applying the script must reproduce b
+			var applied []string
+			for _, e := range result {
+				if e.Op != EditDelete {
+					applied = append(applied, e.Value)
+				}
+			}
+			if len(applied) != len(tt.b) || (len(tt.b) > 0 && !reflect.DeepEqual(applied, tt.b)) {
+				t.Errorf("Applying EditDistance(%v, %v) = %v, expected %v", tt.a, tt.b, applied, tt.b)
+			}
+		})
+	}
+}
+
+func TestEditOp_String(t *testing.T) {
+	for op, expected := range map[EditOp]string{EditKeep: "keep", EditInsert: "insert", EditDelete: "delete", EditOp(9): "unknown"} {
+		if op.String() != expected {
+			t.Errorf("EditOp(%d).String() = %q, expected %q", int(op), op.String(), expected)
+		}
+	}
+}
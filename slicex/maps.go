@@ -0,0 +1,76 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Keys returns the keys of the map, such as one produced by Group.
+// The order is unspecified and varies between calls; use SortedKeys for a
+// deterministic order. Returns nil for an empty map.
+func Keys[K comparable, V any](m map[K]V) []K {
+	if len(m) == 0 {
+		return nil
+	}
+
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// Values returns the values of the map. The order is unspecified and varies
+// between calls. Returns nil for an empty map.
+func Values[K comparable, V any](m map[K]V) []V {
+	if len(m) == 0 {
+		return nil
+	}
+
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+// SortedKeys returns the keys of the map in ascending order.
+// Returns nil for an empty map.
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+
+	return keys
+}
+
+// MapValues returns a new map with the same keys as m and each value transformed
+// by fn, such as turning a Group result into per-key counts. Always returns a
+// non-nil map.
+func MapValues[K comparable, V, R any](m map[K]V, fn func(V) R) map[K]R {
+	result := make(map[K]R, len(m))
+
+	for k, v := range m {
+		result[k] = fn(v)
+	}
+
+	return result
+}
+
+// MapKeys returns a new map with each key of m transformed by fn and the values
+// unchanged. When several keys map to the same result, the last one written wins;
+// since map iteration order is unspecified, which value survives is unspecified
+// too. Always returns a non-nil map.
+func MapKeys[K comparable, V any, R comparable](m map[K]V, fn func(K) R) map[R]V {
+	result := make(map[R]V, len(m))
+
+	for k, v := range m {
+		result[fn(k)] = v
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+	groups := Group([]int{1, 2, 3, 4, 5}, func(n int) string {
+		if n%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+
+	keys := Keys(groups)
+	if len(keys) != 2 {
+		t.Fatalf("Keys(%v) has %d keys, expected 2", groups, len(keys))
+	}
+	for _, k := range []string{"even", "odd"} {
+		if !slices.Contains(keys, k) {
+			t.Errorf("Keys(%v) = %v, expected to contain %q", groups, keys, k)
+		}
+	}
+
+	if result := Keys(map[string]int{}); result != nil {
+		t.Errorf("Keys({}) = %v, expected nil", result)
+	}
+}
+
+func TestValues(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 2}
+
+	values := Values(m)
+	slices.Sort(values)
+	expected := []int{1, 2, 2}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Values(%v) = %v, expected %v", m, values, expected)
+	}
+
+	if result := Values(map[string]int(nil)); result != nil {
+		t.Errorf("Values(nil) = %v, expected nil", result)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	tests := map[string]struct {
+		input    map[string]bool
+		expected []string
+	}{
+		"sorted": {
+			input:    map[string]bool{"pear": true, "apple": true, "fig": false, "banana": true},
+			expected: []string{"apple", "banana", "fig", "pear"},
+		},
+		"empty map": {
+			input:    map[string]bool{},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := SortedKeys(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("SortedKeys(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	groups := Group([]string{"apple", "avocado", "banana", "cherry", "blueberry"}, func(s string) byte { return s[0] })
+
+	counts := MapValues(groups, func(items []string) int { return len(items) })
+	expected := map[byte]int{'a': 2, 'b': 2, 'c': 1}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("MapValues(%v) = %v, expected %v", groups, counts, expected)
+	}
+
+	if result := MapValues(map[string]int(nil), func(n int) int { return n }); result == nil || len(result) != 0 {
+		t.Errorf("MapValues(nil) = %v, expected empty non-nil map", result)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	tests := map[string]struct {
+		input    map[string]int
+		fn       func(string) string
+		expected map[string]int
+	}{
+		"no collisions": {
+			input:    map[string]int{"a": 1, "b": 2},
+			fn:       strings.ToUpper,
+			expected: map[string]int{"A": 1, "B": 2},
+		},
+		"collisions": {
+			input:    map[string]int{"x": 7, "X": 7, "y": 3},
+			fn:       strings.ToLower,
+			expected: map[string]int{"x": 7, "y": 3},
+		},
+		"empty map": {
+			input:    map[string]int{},
+			fn:       strings.ToUpper,
+			expected: map[string]int{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := MapKeys(tt.input, tt.fn)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("MapKeys(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("collision keeps one of the values", func(t *testing.T) {
+		input := map[string]int{"a": 1, "A": 2}
+		result := MapKeys(input, strings.ToLower)
+		if len(result) != 1 || (result["a"] != 1 && result["a"] != 2) {
+			t.Errorf("MapKeys(%v) = %v, expected a single key with value 1 or 2", input, result)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrOverflow is returned by checked arithmetic helpers when a result
+// does not fit in the element type.
+var ErrOverflow = errors.New("integer overflow")
+
+// Signed is a constraint matching all signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint matching all unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint matching all integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint matching all floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint matching all integer and floating-point types.
+type Number interface {
+	Integer | Float
+}
+
+// Sum returns the sum of all elements in the slice, or zero for an empty slice.
+// Integer sums silently wrap around on overflow, following Go's arithmetic rules;
+// use SumChecked when wraparound would be an error.
+func Sum[T Number](slice []T) T {
+	var sum T
+	for _, item := range slice {
+		sum += item
+	}
+
+	return sum
+}
+
+// SumBy returns the sum of fn applied to each element, such as the total value of
+// a slice of orders, or zero for an empty slice. Like Sum, integer sums wrap around
+// on overflow.
+func SumBy[T any, N Number](slice []T, fn func(T) N) N {
+	var sum N
+	for _, item := range slice {
+		sum += fn(item)
+	}
+
+	return sum
+}
+
+// SumChecked returns the sum of all elements in the slice, or an error wrapping
+// ErrOverflow if any intermediate sum overflows the integer type.
+func SumChecked[T Integer](slice []T) (T, error) {
+	var sum T
+	for i, item := range slice {
+		next := sum + item
+		if (item > 0 && next < sum) || (item < 0 && next > sum) {
+			return 0, fmt.Errorf("sum overflows at index %d: %w", i, ErrOverflow)
+		}
+		sum = next
+	}
+
+	return sum, nil
+}
+
+// EqualWithin reports whether a and b have the same length and every pair of
+// elements differs by at most epsilon. NaN values are equal only to NaN values at
+// the same position, so slices with NaN in matching places compare as equal.
+func EqualWithin(a, b []float64, epsilon float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		x, y := a[i], b[i]
+		if x == y || (math.IsNaN(x) && math.IsNaN(y)) {
+			continue
+		}
+		if !(math.Abs(x-y) <= epsilon) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MovingAverage returns the simple moving average of each full window of the given
+// size, so the result has len(slice)-window+1 elements, or none if the window is
+// longer than the slice. It panics if window is not positive.
+func MovingAverage[T Number](slice []T, window int) []float64 {
+	if window <= 0 {
+		panic(fmt.Sprintf("slicex: MovingAverage window must be positive, got %d", window))
+	}
+	if window > len(slice) {
+		return nil
+	}
+
+	result := make([]float64, 0, len(slice)-window+1)
+	var sum float64
+	for i, item := range slice {
+		sum += float64(item)
+		if i >= window {
+			sum -= float64(slice[i-window])
+		}
+		if i >= window-1 {
+			result = append(result, sum/float64(window))
+		}
+	}
+
+	return result
+}
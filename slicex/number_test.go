@@ -0,0 +1,192 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	t.Run("ints", func(t *testing.T) {
+		if result := Sum([]int{1, 2, 3, 4}); result != 10 {
+			t.Errorf("Sum([1 2 3 4]) = %d, expected 10", result)
+		}
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		if result := Sum([]float64{0.5, 1.5, 2}); result != 4 {
+			t.Errorf("Sum([0.5 1.5 2]) = %v, expected 4", result)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		if result := Sum([]int{}); result != 0 {
+			t.Errorf("Sum([]) = %d, expected 0", result)
+		}
+	})
+}
+
+func TestSumBy(t *testing.T) {
+	type order struct {
+		Items int
+		Value float64
+	}
+	orders := []order{{2, 10.5}, {1, 4.25}, {3, 5.25}}
+
+	t.Run("floats", func(t *testing.T) {
+		if result := SumBy(orders, func(o order) float64 { return o.Value }); result != 20 {
+			t.Errorf("SumBy(value) = %v, expected 20", result)
+		}
+	})
+
+	t.Run("ints", func(t *testing.T) {
+		if result := SumBy(orders, func(o order) int { return o.Items }); result != 6 {
+			t.Errorf("SumBy(items) = %d, expected 6", result)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		if result := SumBy([]order{}, func(o order) float64 { return o.Value }); result != 0 {
+			t.Errorf("SumBy([]) = %v, expected 0", result)
+		}
+	})
+}
+
+func TestSumChecked(t *testing.T) {
+	t.Run("normal sum", func(t *testing.T) {
+		result, err := SumChecked([]int64{100, -20, 30})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result != 110 {
+			t.Errorf("SumChecked = %d, expected 110", result)
+		}
+	})
+
+	t.Run("signed overflow", func(t *testing.T) {
+		_, err := SumChecked([]int64{math.MaxInt64, 1})
+		if !errors.Is(err, ErrOverflow) {
+			t.Errorf("Expected ErrOverflow, got %v", err)
+		}
+	})
+
+	t.Run("signed underflow", func(t *testing.T) {
+		_, err := SumChecked([]int8{-100, -100})
+		if !errors.Is(err, ErrOverflow) {
+			t.Errorf("Expected ErrOverflow, got %v", err)
+		}
+	})
+
+	t.Run("unsigned overflow", func(t *testing.T) {
+		_, err := SumChecked([]uint8{200, 100})
+		if !errors.Is(err, ErrOverflow) {
+			t.Errorf("Expected ErrOverflow, got %v", err)
+		}
+	})
+}
+
+func TestEqualWithin(t *testing.T) {
+	tests := map[string]struct {
+		a, b     []float64
+		epsilon  float64
+		expected bool
+	}{
+		"within tolerance": {
+			a:        []float64{0.1 + 0.2, 1.0},
+			b:        []float64{0.3, 1.0000001},
+			epsilon:  1e-6,
+			expected: true,
+		},
+		"outside tolerance": {
+			a:        []float64{1.0, 2.0},
+			b:        []float64{1.0, 2.1},
+			epsilon:  0.01,
+			expected: false,
+		},
+		"NaN matches NaN": {
+			a:        []float64{math.NaN(), 1},
+			b:        []float64{math.NaN(), 1},
+			epsilon:  1e-9,
+			expected: true,
+		},
+		"NaN does not match number": {
+			a:        []float64{math.NaN()},
+			b:        []float64{0},
+			epsilon:  math.Inf(1),
+			expected: false,
+		},
+		"matching infinities": {
+			a:        []float64{math.Inf(1), math.Inf(-1)},
+			b:        []float64{math.Inf(1), math.Inf(-1)},
+			epsilon:  1e-9,
+			expected: true,
+		},
+		"differing lengths": {
+			a:        []float64{1, 2},
+			b:        []float64{1},
+			epsilon:  1,
+			expected: false,
+		},
+		"both empty": {
+			a:        nil,
+			b:        []float64{},
+			epsilon:  0,
+			expected: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := EqualWithin(tt.a, tt.b, tt.epsilon); result != tt.expected {
+				t.Errorf("EqualWithin(%v, %v, %v) = %v, expected %v", tt.a, tt.b, tt.epsilon, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	t.Run("known series", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6}
+		result := MovingAverage(input, 3)
+
+		expected := []float64{2, 3, 4, 5}
+		if len(result) != len(input)-3+1 {
+			t.Errorf("Expected %d averages, got %d", len(input)-3+1, len(result))
+		}
+		if !EqualWithin(result, expected, 1e-9) {
+			t.Errorf("MovingAverage(%v, 3) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		result := MovingAverage([]float64{1.5, 2.5, 4}, 2)
+		if !EqualWithin(result, []float64{2, 3.25}, 1e-9) {
+			t.Errorf("MovingAverage = %v, expected [2 3.25]", result)
+		}
+	})
+
+	t.Run("window equals length", func(t *testing.T) {
+		result := MovingAverage([]int{2, 4}, 2)
+		if !EqualWithin(result, []float64{3}, 1e-9) {
+			t.Errorf("MovingAverage = %v, expected [3]", result)
+		}
+	})
+
+	t.Run("window longer than slice", func(t *testing.T) {
+		if result := MovingAverage([]int{1, 2}, 3); result != nil {
+			t.Errorf("MovingAverage = %v, expected nil", result)
+		}
+	})
+
+	t.Run("non-positive window panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for window 0")
+			}
+		}()
+		MovingAverage([]int{1}, 0)
+	})
+}
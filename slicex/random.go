@@ -0,0 +1,92 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+)
+
+// WeightedSample selects n items at random, with replacement, where each draw picks
+// items[i] with probability weights[i] / sum(weights). Because sampling is with
+// replacement, the same item may be selected more than once and n may exceed
+// len(items). If r is nil, the global math/rand/v2 source is used.
+// Returns an error if the lengths differ, n is negative, any weight is negative or
+// not finite, the weights sum to more than the largest float64, or all weights are
+// zero.
+func WeightedSample[T any](items []T, weights []float64, n int, r *rand.Rand) ([]T, error) {
+	if len(items) != len(weights) {
+		return nil, fmt.Errorf("items and weights must have the same length, got %d and %d", len(items), len(weights))
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("sample size cannot be negative: %d", n)
+	}
+
+	cumulative := make([]float64, len(weights))
+	var total float64
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("invalid weight at index %d: %v", i, w)
+		}
+		total += w
+		cumulative[i] = total
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if total == 0 {
+		return nil, errors.New("weights must not all be zero")
+	}
+	if math.IsInf(total, 0) {
+		return nil, errors.New("sum of weights overflows float64")
+	}
+
+	float64n := rand.Float64
+	if r != nil {
+		float64n = r.Float64
+	}
+
+	result := make([]T, n)
+	for i := range result {
+		target := float64n() * total
+		// First item whose cumulative weight exceeds the target; zero-weight items
+		// share their predecessor's cumulative weight and are never selected.
+		idx := sort.Search(len(cumulative), func(j int) bool { return cumulative[j] > target })
+		result[i] = items[idx]
+	}
+
+	return result, nil
+}
+
+// Shuffle randomly reorders the slice in place using the global math/rand/v2
+// source. Use Shuffled to leave the input unmodified, or SeededShuffle for a
+// reproducible order.
+func Shuffle[T any](slice []T) {
+	rand.Shuffle(len(slice), func(i, j int) { slice[i], slice[j] = slice[j], slice[i] })
+}
+
+// SeededShuffle reorders the slice in place like Shuffle, but deterministically:
+// the same seed always produces the same permutation of a slice of a given length,
+// which keeps tests of code that randomizes its input stable.
+func SeededShuffle[T any](slice []T, seed uint64) {
+	r := rand.New(rand.NewPCG(seed, seed))
+	r.Shuffle(len(slice), func(i, j int) { slice[i], slice[j] = slice[j], slice[i] })
+}
+
+// Shuffled returns a randomly reordered copy of the slice, leaving the input
+// unmodified. Returns nil for an empty slice.
+func Shuffled[T any](slice []T) []T {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	result := slices.Clone(slice)
+	Shuffle(result)
+
+	return result
+}
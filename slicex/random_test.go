@@ -0,0 +1,130 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestWeightedSample(t *testing.T) {
+	t.Run("empirical frequencies approximate weights", func(t *testing.T) {
+		items := []string{"a", "b", "c", "never"}
+		weights := []float64{1, 2, 7, 0}
+		r := rand.New(rand.NewPCG(1, 2))
+
+		const draws = 100_000
+		result, err := WeightedSample(items, weights, draws, r)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(result) != draws {
+			t.Fatalf("Expected %d draws, got %d", draws, len(result))
+		}
+
+		counts := make(map[string]int)
+		for _, item := range result {
+			counts[item]++
+		}
+
+		expected := map[string]float64{"a": 0.1, "b": 0.2, "c": 0.7, "never": 0}
+		for item, p := range expected {
+			freq := float64(counts[item]) / draws
+			if math.Abs(freq-p) > 0.01 {
+				t.Errorf("Frequency of %q = %.3f, expected about %.3f", item, freq, p)
+			}
+		}
+	})
+
+	t.Run("nil source uses global randomness", func(t *testing.T) {
+		result, err := WeightedSample([]int{1, 2}, []float64{0, 1}, 5, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, item := range result {
+			if item != 2 {
+				t.Errorf("Expected only item 2 to be drawn, got %d", item)
+			}
+		}
+	})
+
+	t.Run("zero draws", func(t *testing.T) {
+		result, err := WeightedSample([]int{1}, []float64{1}, 0, nil)
+		if err != nil || result != nil {
+			t.Errorf("Expected nil, nil, got %v, %v", result, err)
+		}
+	})
+
+	errorCases := map[string]struct {
+		items   []int
+		weights []float64
+		n       int
+	}{
+		"mismatched lengths":  {items: []int{1, 2}, weights: []float64{1}, n: 1},
+		"negative weight":     {items: []int{1, 2}, weights: []float64{1, -1}, n: 1},
+		"NaN weight":          {items: []int{1}, weights: []float64{math.NaN()}, n: 1},
+		"all zero weights":    {items: []int{1, 2}, weights: []float64{0, 0}, n: 1},
+		"weight sum overflow": {items: []int{1, 2}, weights: []float64{math.MaxFloat64, math.MaxFloat64}, n: 1},
+		"negative n":          {items: []int{1}, weights: []float64{1}, n: -1},
+	}
+
+	for name, tt := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := WeightedSample(tt.items, tt.weights, tt.n, nil); err == nil {
+				t.Error("Expected error but got none")
+			}
+		})
+	}
+}
+
+func TestSeededShuffle(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	first := slices.Clone(input)
+	SeededShuffle(first, 42)
+	second := slices.Clone(input)
+	SeededShuffle(second, 42)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("SeededShuffle with the same seed = %v and %v, expected identical", first, second)
+	}
+	if !ElementsMatch(first, input) {
+		t.Errorf("SeededShuffle(%v) = %v, expected a permutation", input, first)
+	}
+	if reflect.DeepEqual(first, input) {
+		t.Errorf("SeededShuffle(%v) left the input in its original order", input)
+	}
+
+	other := slices.Clone(input)
+	SeededShuffle(other, 7)
+	if reflect.DeepEqual(first, other) {
+		t.Errorf("SeededShuffle with seeds 42 and 7 both produced %v", first)
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	input := []string{"a", "b", "c", "d", "e"}
+
+	shuffled := slices.Clone(input)
+	Shuffle(shuffled)
+	if !ElementsMatch(shuffled, input) {
+		t.Errorf("Shuffle(%v) = %v, expected a permutation", input, shuffled)
+	}
+
+	original := slices.Clone(input)
+	result := Shuffled(input)
+	if !reflect.DeepEqual(input, original) {
+		t.Errorf("Shuffled modified its input: %v", input)
+	}
+	if !ElementsMatch(result, input) {
+		t.Errorf("Shuffled(%v) = %v, expected a permutation", input, result)
+	}
+
+	if result := Shuffled([]int{}); result != nil {
+		t.Errorf("Shuffled([]) = %v, expected nil", result)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import "iter"
+
+// MapSeq returns an iterator that lazily applies fn to each value produced by seq.
+// Nothing is materialized: fn is called only as values are pulled, and stopping the
+// returned iterator early also stops seq.
+func MapSeq[T, R any](seq iter.Seq[T], fn func(T) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for v := range seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq returns an iterator that lazily yields the values of seq for which
+// predicate returns true. Like MapSeq, stopping the returned iterator early also
+// stops seq.
+func FilterSeq[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if predicate(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// CollectSeq materializes the values of seq into a slice, in order. Returns nil if
+// seq yields nothing. It is named CollectSeq because Collect already gathers the
+// results of a fallible function.
+func CollectSeq[T any](seq iter.Seq[T]) []T {
+	var result []T
+	for v := range seq {
+		result = append(result, v)
+	}
+
+	return result
+}
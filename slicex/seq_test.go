@@ -0,0 +1,126 @@
+// Copyright (c) 2025 letmevibethatforyou
+// SPDX-License-Identifier: MIT
+
+package slicex
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMapSeq(t *testing.T) {
+	t.Run("maps values", func(t *testing.T) {
+		result := slices.Collect(MapSeq(slices.Values([]int{1, 2, 3}), strconv.Itoa))
+		expected := []string{"1", "2", "3"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapSeq([1 2 3]) = %v, expected %v", result, expected)
+		}
+	})
+
+	t.Run("lazy with early break", func(t *testing.T) {
+		var pulled, mapped int
+		naturals := func(yield func(int) bool) {
+			for i := 0; ; i++ {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+
+		var result []int
+		for v := range MapSeq(naturals, func(n int) int { mapped++; return n * n }) {
+			if v > 10 {
+				break
+			}
+			result = append(result, v)
+		}
+
+		expected := []int{0, 1, 4, 9}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapSeq(naturals) = %v, expected %v", result, expected)
+		}
+		if pulled != 5 || mapped != 5 {
+			t.Errorf("Expected 5 values pulled and mapped, got %d and %d", pulled, mapped)
+		}
+	})
+
+	t.Run("composes", func(t *testing.T) {
+		doubled := MapSeq(slices.Values([]int{1, 2}), func(n int) int { return n * 2 })
+		result := slices.Collect(MapSeq(doubled, strconv.Itoa))
+		expected := []string{"2", "4"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("MapSeq(MapSeq(...)) = %v, expected %v", result, expected)
+		}
+	})
+}
+
+func TestFilterSeq(t *testing.T) {
+	t.Run("filters values", func(t *testing.T) {
+		result := CollectSeq(FilterSeq(slices.Values([]int{1, 2, 3, 4, 5}), func(n int) bool { return n%2 == 1 }))
+		expected := []int{1, 3, 5}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FilterSeq([1 2 3 4 5]) = %v, expected %v", result, expected)
+		}
+	})
+
+	t.Run("lazy with early break", func(t *testing.T) {
+		var pulled int
+		naturals := func(yield func(int) bool) {
+			for i := 0; ; i++ {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+
+		var result []int
+		for v := range FilterSeq(naturals, func(n int) bool { return n%3 == 0 }) {
+			result = append(result, v)
+			if len(result) == 3 {
+				break
+			}
+		}
+
+		expected := []int{0, 3, 6}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FilterSeq(naturals) = %v, expected %v", result, expected)
+		}
+		if pulled != 7 {
+			t.Errorf("Expected 7 values pulled, got %d", pulled)
+		}
+	})
+}
+
+func TestCollectSeq(t *testing.T) {
+	t.Run("composes MapSeq into FilterSeq", func(t *testing.T) {
+		var pulled int
+		source := func(yield func(int) bool) {
+			for _, v := range []int{1, 2, 3, 4, 5, 6} {
+				pulled++
+				if !yield(v) {
+					return
+				}
+			}
+		}
+
+		squares := MapSeq(source, func(n int) int { return n * n })
+		result := CollectSeq(FilterSeq(squares, func(n int) bool { return n > 10 }))
+		expected := []int{16, 25, 36}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("CollectSeq(FilterSeq(MapSeq(...))) = %v, expected %v", result, expected)
+		}
+		if pulled != 6 {
+			t.Errorf("Expected 6 values pulled, got %d", pulled)
+		}
+	})
+
+	t.Run("empty sequence", func(t *testing.T) {
+		if result := CollectSeq(slices.Values([]int{})); result != nil {
+			t.Errorf("CollectSeq(empty) = %v, expected nil", result)
+		}
+	})
+}
@@ -5,9 +5,15 @@
 package slicex
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 )
 
 // Unique returns a new slice containing only unique elements from the input slice,
@@ -30,6 +36,84 @@ func Unique[T comparable](slice []T) []T {
 	return result
 }
 
+// UniqueBy returns a new slice containing only the first element for each distinct
+// key produced by keyFn, preserving order. It dedupes elements that are not
+// comparable, or by a single field. Returns nil for an empty slice.
+func UniqueBy[T any, K comparable](slice []T, keyFn func(T) K) []T {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	seen := make(map[K]bool)
+	result := make([]T, 0, len(slice))
+
+	for _, item := range slice {
+		key := keyFn(item)
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// UniqueHashable returns a new slice containing only the first element for each
+// distinct key produced by hashFn, preserving order. It extends Unique to element
+// types that are not comparable, such as structs with slice fields.
+func UniqueHashable[T any](slice []T, hashFn func(T) string) []T {
+	return UniqueBy(slice, hashFn)
+}
+
+// UniqueWithDuplicates is like Unique but also returns the elements it removed,
+// in the order they were encountered as repeats, so callers can report what was
+// dropped. A value that appears n times is in unique once and in duplicates n-1
+// times. Either slice is nil when it would be empty.
+func UniqueWithDuplicates[T comparable](slice []T) (unique, duplicates []T) {
+	if len(slice) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[T]bool)
+	unique = make([]T, 0, len(slice))
+
+	for _, item := range slice {
+		if seen[item] {
+			duplicates = append(duplicates, item)
+			continue
+		}
+		seen[item] = true
+		unique = append(unique, item)
+	}
+
+	return unique, duplicates
+}
+
+// Compact returns a new slice in which each run of consecutive equal elements is
+// collapsed to its first element. Unlike Unique, equal elements that are not adjacent
+// are kept, so it reports the state transitions of an ordered stream.
+// The input is not modified. Returns nil for an empty slice.
+func Compact[T comparable](slice []T) []T {
+	return CompactFunc(slice, func(a, b T) bool { return a == b })
+}
+
+// CompactFunc is like Compact but uses eq to decide whether adjacent elements are
+// equal, for element types that are not comparable or for custom equality.
+func CompactFunc[T any](slice []T, eq func(a, b T) bool) []T {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	result := []T{slice[0]}
+	for _, item := range slice[1:] {
+		if !eq(result[len(result)-1], item) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
 // FilterNonZero returns a new slice with all non-zero values from the input slice.
 // Zero values are determined by Go's zero value concept (0, "", nil, etc.).
 func FilterNonZero[T comparable](slice []T) []T {
@@ -45,6 +129,333 @@ func FilterNonZero[T comparable](slice []T) []T {
 	return result
 }
 
+// Filter returns a new slice containing only the elements for which keep returns
+// true, preserving order. Returns nil if the slice is empty or no element is kept.
+func Filter[T any](slice []T, keep func(T) bool) []T {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	result := make([]T, 0, len(slice))
+	for _, item := range slice {
+		if keep(item) {
+			result = append(result, item)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// Reject is the complement of Filter: it returns a new slice containing only the
+// elements for which the predicate returns false, preserving order.
+func Reject[T any](slice []T, predicate func(T) bool) []T {
+	return Filter(slice, func(item T) bool { return !predicate(item) })
+}
+
+// Without returns a new slice with every occurrence of the given values removed,
+// preserving the order of the remaining elements.
+func Without[T comparable](slice []T, remove ...T) []T {
+	drop := make(map[T]bool, len(remove))
+	for _, item := range remove {
+		drop[item] = true
+	}
+
+	result := make([]T, 0, len(slice))
+	for _, item := range slice {
+		if !drop[item] {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// Split slices the input into the sub-slices separated by each occurrence of sep,
+// dropping the separators, like strings.Split. Leading, trailing and adjacent
+// separators produce empty sub-slices; input without separators yields a single group.
+// The sub-slices share the input's backing array but are capped so that appending
+// to one cannot overwrite the rest of the input. Returns nil for an empty slice.
+func Split[T comparable](slice []T, sep T) [][]T {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	var result [][]T
+	start := 0
+	for i, item := range slice {
+		if item == sep {
+			result = append(result, slice[start:i:i])
+			start = i + 1
+		}
+	}
+	result = append(result, slice[start:len(slice):len(slice)])
+
+	return result
+}
+
+// ChunkMode selects how ChunkIntoMode distributes the remainder when the slice
+// length is not evenly divisible by the number of chunks.
+type ChunkMode int
+
+const (
+	// ChunkFrontLoaded gives the extra elements to the first chunks.
+	ChunkFrontLoaded ChunkMode = iota
+	// ChunkBackLoaded gives the extra elements to the last chunks.
+	ChunkBackLoaded
+	// ChunkRoundRobin deals elements to the chunks in turn, so element i lands in
+	// chunk i%n. Chunk sizes match ChunkFrontLoaded but chunks are not contiguous.
+	ChunkRoundRobin
+)
+
+// ChunkInto splits the slice into n chunks whose sizes differ by at most one,
+// with the larger chunks first. It is ChunkIntoMode with ChunkFrontLoaded.
+func ChunkInto[T any](slice []T, n int) [][]T {
+	return ChunkIntoMode(slice, n, ChunkFrontLoaded)
+}
+
+// ChunkIntoMode splits the slice into n chunks whose sizes differ by at most one,
+// distributing the remainder according to mode. Fewer than n chunks are returned
+// when the slice has fewer than n elements, so no chunk is empty. Contiguous chunks
+// share the input's backing array but are capped so that appending to one cannot
+// overwrite the rest of the input. Returns nil for an empty slice and panics if n
+// is not positive.
+func ChunkIntoMode[T any](slice []T, n int, mode ChunkMode) [][]T {
+	if n <= 0 {
+		panic(fmt.Sprintf("slicex: ChunkIntoMode chunk count must be positive, got %d", n))
+	}
+	if len(slice) == 0 {
+		return nil
+	}
+	n = min(n, len(slice))
+
+	result := make([][]T, n)
+	if mode == ChunkRoundRobin {
+		for i, item := range slice {
+			result[i%n] = append(result[i%n], item)
+		}
+		return result
+	}
+
+	size, remainder := len(slice)/n, len(slice)%n
+	start := 0
+	for i := range n {
+		end := start + size
+		if mode == ChunkBackLoaded && i >= n-remainder {
+			end++
+		} else if mode != ChunkBackLoaded && i < remainder {
+			end++
+		}
+		result[i] = slice[start:end:end]
+		start = end
+	}
+
+	return result
+}
+
+// SortBy sorts the slice in place in ascending order of the key extracted by keyFn.
+// The sort is stable: elements with equal keys keep their original relative order.
+func SortBy[T any, K cmp.Ordered](slice []T, keyFn func(T) K) {
+	slices.SortStableFunc(slice, func(a, b T) int {
+		return cmp.Compare(keyFn(a), keyFn(b))
+	})
+}
+
+// SortByDesc is like SortBy but sorts in descending order of the key. It is also
+// stable, so elements with equal keys keep their original relative order.
+func SortByDesc[T any, K cmp.Ordered](slice []T, keyFn func(T) K) {
+	slices.SortStableFunc(slice, func(a, b T) int {
+		return cmp.Compare(keyFn(b), keyFn(a))
+	})
+}
+
+// SortedBy returns a copy of the slice stably sorted in ascending order of the key,
+// leaving the input untouched. Returns nil for an empty slice.
+func SortedBy[T any, K cmp.Ordered](slice []T, keyFn func(T) K) []T {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(slice)
+	SortBy(sorted, keyFn)
+	return sorted
+}
+
+// SortedByDesc returns a copy of the slice stably sorted in descending order of the
+// key, leaving the input untouched. Returns nil for an empty slice.
+func SortedByDesc[T any, K cmp.Ordered](slice []T, keyFn func(T) K) []T {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(slice)
+	SortByDesc(sorted, keyFn)
+	return sorted
+}
+
+// MinBy returns the element with the smallest key extracted by keyFn, such as the
+// youngest person. If several elements tie, the first is returned.
+// The bool is false, with the zero value of T, for an empty slice.
+func MinBy[T any, K cmp.Ordered](slice []T, keyFn func(T) K) (T, bool) {
+	return extremeBy(slice, keyFn, -1)
+}
+
+// MaxBy returns the element with the largest key extracted by keyFn, such as the
+// oldest person. If several elements tie, the first is returned.
+// The bool is false, with the zero value of T, for an empty slice.
+func MaxBy[T any, K cmp.Ordered](slice []T, keyFn func(T) K) (T, bool) {
+	return extremeBy(slice, keyFn, 1)
+}
+
+// extremeBy returns the first element whose key compares as sign (-1 for the
+// minimum, +1 for the maximum) against every other key.
+func extremeBy[T any, K cmp.Ordered](slice []T, keyFn func(T) K, sign int) (T, bool) {
+	if len(slice) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	best, bestKey := slice[0], keyFn(slice[0])
+	for _, item := range slice[1:] {
+		if key := keyFn(item); cmp.Compare(key, bestKey) == sign {
+			best, bestKey = item, key
+		}
+	}
+
+	return best, true
+}
+
+// QuantileBuckets sorts the elements by the extracted key, in ascending order and
+// stably, and splits them into the given number of buckets of roughly equal size
+// (quartiles, deciles, etc.), with any larger buckets first. Fewer buckets are
+// returned if there are fewer elements than buckets. The input slice is not modified.
+// Returns nil for an empty slice and panics if buckets is not positive.
+func QuantileBuckets[T any, K cmp.Ordered](slice []T, keyFn func(T) K, buckets int) [][]T {
+	if buckets <= 0 {
+		panic(fmt.Sprintf("slicex: QuantileBuckets bucket count must be positive, got %d", buckets))
+	}
+
+	return ChunkInto(SortedBy(slice, keyFn), buckets)
+}
+
+// ChunkStride returns chunks of up to size elements whose start positions advance by
+// stride: stride < size yields overlapping windows, stride == size yields consecutive
+// chunks, and stride > size skips elements between chunks. Chunking stops after the
+// first chunk that reaches the end of the slice, which may be shorter than size.
+// Chunks share the input's backing array but are capped so that appending to one
+// cannot overwrite the rest of the input. Returns nil for an empty slice and panics
+// if size or stride is not positive.
+func ChunkStride[T any](slice []T, size, stride int) [][]T {
+	if size <= 0 || stride <= 0 {
+		panic(fmt.Sprintf("slicex: ChunkStride size and stride must be positive, got %d and %d", size, stride))
+	}
+	if len(slice) == 0 {
+		return nil
+	}
+
+	var result [][]T
+	for start := 0; start < len(slice); start += stride {
+		end := min(start+size, len(slice))
+		result = append(result, slice[start:end:end])
+		if end == len(slice) {
+			break
+		}
+	}
+
+	return result
+}
+
+// Window returns every contiguous sub-slice of exactly size elements, sliding one
+// position at a time, so a slice of length n yields n-size+1 windows. It is useful
+// for moving computations and pattern detection over ordered data. The windows
+// share the input's backing array but are capped so that appending to one cannot
+// overwrite the rest of the input. Returns nil if size exceeds the slice length and
+// an error if size is not positive.
+func Window[T any](slice []T, size int) ([][]T, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("window size must be positive, got %d", size)
+	}
+	if size > len(slice) {
+		return nil, nil
+	}
+
+	return ChunkStride(slice, size, 1), nil
+}
+
+// Run is a value repeated Count times consecutively, as produced by RunLengthEncode.
+type Run[T any] struct {
+	Value T
+	Count int
+}
+
+// RunLengthEncode collapses each run of consecutive equal elements into a single
+// Run holding the value and the run length. Returns nil for an empty slice.
+func RunLengthEncode[T comparable](slice []T) []Run[T] {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	var result []Run[T]
+	for _, item := range slice {
+		if n := len(result); n > 0 && result[n-1].Value == item {
+			result[n-1].Count++
+			continue
+		}
+		result = append(result, Run[T]{Value: item, Count: 1})
+	}
+
+	return result
+}
+
+// RunLengthDecode expands runs back into the original sequence, reversing
+// RunLengthEncode. Runs with a non-positive count contribute nothing.
+// Returns nil if the runs expand to no elements.
+func RunLengthDecode[T any](runs []Run[T]) []T {
+	total := 0
+	for _, run := range runs {
+		total += max(run.Count, 0)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	result := make([]T, 0, total)
+	for _, run := range runs {
+		for range run.Count {
+			result = append(result, run.Value)
+		}
+	}
+
+	return result
+}
+
+// Reverse reverses the order of the elements in place, mutating the slice.
+// Use Reversed to leave the input untouched.
+func Reverse[T any](slice []T) {
+	for i, j := 0, len(slice)-1; i < j; i, j = i+1, j-1 {
+		slice[i], slice[j] = slice[j], slice[i]
+	}
+}
+
+// Reversed returns a new slice with the elements in reverse order. The input is
+// not modified, so it is safe to use on slices shared with other goroutines.
+// Returns nil for an empty slice.
+func Reversed[T any](slice []T) []T {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	result := make([]T, len(slice))
+	for i, item := range slice {
+		result[len(slice)-1-i] = item
+	}
+
+	return result
+}
+
 // Map applies the given function to each element of the slice and returns
 // a new slice containing the results.
 func Map[T, R any](slice []T, fn func(T) R) []R {
@@ -60,29 +471,534 @@ func Map[T, R any](slice []T, fn func(T) R) []R {
 	return result
 }
 
+// ForEach calls fn with the index and value of each element of the slice, in order.
+func ForEach[T any](slice []T, fn func(i int, item T)) {
+	for i, item := range slice {
+		fn(i, item)
+	}
+}
+
+// ForEachErr calls fn with the index and value of each element of the slice, in
+// order, and stops at the first error. The returned error wraps it with the index,
+// such as "item 3: ...".
+func ForEachErr[T any](slice []T, fn func(i int, item T) error) error {
+	for i, item := range slice {
+		if err := fn(i, item); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// FlatMap applies fn to each element and concatenates the resulting slices in order.
+// Nil or empty results contribute nothing. fn is called once per element and the
+// result is allocated once. Returns nil if the slice is empty or every result is empty.
+func FlatMap[T, R any](slice []T, fn func(T) []R) []R {
+	return Flatten(Map(slice, fn))
+}
+
+// Flatten concatenates the inner slices in order into a single slice, allocated
+// once at the combined length. Empty and nil inner slices contribute nothing.
+// Returns nil if there are no elements.
+func Flatten[T any](slices [][]T) []T {
+	total := 0
+	for _, inner := range slices {
+		total += len(inner)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	result := make([]T, 0, total)
+	for _, inner := range slices {
+		result = append(result, inner...)
+	}
+
+	return result
+}
+
+// Flatten2 concatenates a doubly nested slice into a single slice, preserving order.
+// Empty and nil inner slices contribute nothing. Returns nil if there are no elements.
+func Flatten2[T any](slices [][][]T) []T {
+	total := 0
+	for _, outer := range slices {
+		for _, inner := range outer {
+			total += len(inner)
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	result := make([]T, 0, total)
+	for _, outer := range slices {
+		for _, inner := range outer {
+			result = append(result, inner...)
+		}
+	}
+
+	return result
+}
+
+// Concat appends the given slices, in order, into one slice allocated once at the
+// combined length. It is the variadic form of Flatten. Returns nil if there are no
+// elements, including when called with no arguments.
+func Concat[T any](slices ...[]T) []T {
+	return Flatten(slices)
+}
+
+// Interleave merges the slices round-robin: index 0 of each slice in argument
+// order, then index 1 of each, and so on. Slices that run out early are skipped,
+// so every element appears exactly once. Useful for fairly merging several ranked
+// sources. Returns nil if there are no elements.
+func Interleave[T any](slices ...[]T) []T {
+	total, longest := 0, 0
+	for _, s := range slices {
+		total += len(s)
+		longest = max(longest, len(s))
+	}
+	if total == 0 {
+		return nil
+	}
+
+	result := make([]T, 0, total)
+	for i := range longest {
+		for _, s := range slices {
+			if i < len(s) {
+				result = append(result, s[i])
+			}
+		}
+	}
+
+	return result
+}
+
+// ZipWith applies fn to the elements of a and b pairwise and returns the results.
+// Pairing stops at the end of the shorter slice. Returns nil if either slice is empty.
+func ZipWith[A, B, C any](a []A, b []B, fn func(A, B) C) []C {
+	n := min(len(a), len(b))
+	if n == 0 {
+		return nil
+	}
+
+	result := make([]C, n)
+	for i := range n {
+		result[i] = fn(a[i], b[i])
+	}
+
+	return result
+}
+
+// Zip pairs the elements of as and bs by index. When the lengths differ, the
+// result is truncated to the shorter slice and the extra elements are ignored.
+// Returns nil if either slice is empty.
+func Zip[A, B any](as []A, bs []B) []Pair[A, B] {
+	return ZipWith(as, bs, func(a A, b B) Pair[A, B] { return Pair[A, B]{First: a, Second: b} })
+}
+
+// Unzip splits pairs into a slice of first elements and a slice of second elements,
+// reversing Zip. Returns nil slices if pairs is empty.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	as := make([]A, len(pairs))
+	bs := make([]B, len(pairs))
+	for i, p := range pairs {
+		as[i], bs[i] = p.First, p.Second
+	}
+
+	return as, bs
+}
+
+// Merge combines two slices that are already sorted in ascending order into a new
+// sorted slice in linear time, without re-sorting. Equal elements from a come
+// before those from b. Returns nil if both slices are empty.
+func Merge[T cmp.Ordered](a, b []T) []T {
+	return MergeFunc(a, b, cmp.Compare[T])
+}
+
+// MergeFunc is like Merge but orders elements with cmpFn, which must return a
+// negative number when x < y, zero when they are equal and a positive number when
+// x > y. Both inputs must already be sorted by cmpFn.
+func MergeFunc[T any](a, b []T, cmpFn func(x, y T) int) []T {
+	if len(a)+len(b) == 0 {
+		return nil
+	}
+
+	result := make([]T, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if cmpFn(b[j], a[i]) < 0 {
+			result = append(result, b[j])
+			j++
+		} else {
+			result = append(result, a[i])
+			i++
+		}
+	}
+	result = append(result, a[i:]...)
+	result = append(result, b[j:]...)
+
+	return result
+}
+
+// Collect applies a fallible function to each element in order and returns the
+// successful results and the errors separately, each preserving input order.
+// Either slice is nil when it would be empty.
+func Collect[T, R any](slice []T, fn func(T) (R, error)) ([]R, []error) {
+	var results []R
+	var errs []error
+
+	for _, item := range slice {
+		v, err := fn(item)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, v)
+	}
+
+	return results, errs
+}
+
+// SplitResults compacts parallel result and error slices, where errs[i] reports
+// the failure of results[i], into only the successful results and only the
+// non-nil errors. Indices beyond the end of errs are treated as successes.
+func SplitResults[R any](results []R, errs []error) ([]R, []error) {
+	var successes []R
+	var failures []error
+
+	for i, v := range results {
+		if i < len(errs) && errs[i] != nil {
+			continue
+		}
+		successes = append(successes, v)
+	}
+	for _, err := range errs {
+		if err != nil {
+			failures = append(failures, err)
+		}
+	}
+
+	return successes, failures
+}
+
 // Group groups the elements of the slice by the mapConcurrentResult of the key function.
 // Returns a map where keys are the grouping criteria and values are slices
 // of grouped items.
 func Group[T any, K comparable](slice []T, keyFn func(T) K) map[K][]T {
 	result := make(map[K][]T)
 
-	for _, item := range slice {
-		key := keyFn(item)
-		result[key] = append(result[key], item)
+	for _, item := range slice {
+		key := keyFn(item)
+		result[key] = append(result[key], item)
+	}
+
+	return result
+}
+
+// Count returns the number of elements equal to target.
+func Count[T comparable](slice []T, target T) int {
+	n := 0
+	for _, item := range slice {
+		if item == target {
+			n++
+		}
+	}
+
+	return n
+}
+
+// CountBy returns the number of elements for each key produced by keyFn. It is
+// equivalent to taking the lengths of Group's result without retaining the elements.
+func CountBy[T any, K comparable](slice []T, keyFn func(T) K) map[K]int {
+	result := make(map[K]int)
+
+	for _, item := range slice {
+		result[keyFn(item)]++
+	}
+
+	return result
+}
+
+// GroupSorted groups the elements of the slice like Group and also returns the
+// group keys in ascending order, so callers can iterate the groups deterministically.
+func GroupSorted[T any, K cmp.Ordered](slice []T, keyFn func(T) K) ([]K, map[K][]T) {
+	groups := Group(slice, keyFn)
+	return SortedKeys(groups), groups
+}
+
+// Reduce folds the slice from left to right, passing the running accumulator and
+// each element to fn, starting from initial. Returns initial for an empty slice.
+func Reduce[T, R any](slice []T, initial R, fn func(acc R, item T) R) R {
+	acc := initial
+	for _, item := range slice {
+		acc = fn(acc, item)
+	}
+
+	return acc
+}
+
+// ReduceRight is like Reduce but walks the slice from the last element to the first,
+// for building right-associative results.
+func ReduceRight[T, R any](slice []T, initial R, fn func(acc R, item T) R) R {
+	acc := initial
+	for i := len(slice) - 1; i >= 0; i-- {
+		acc = fn(acc, slice[i])
+	}
+
+	return acc
+}
+
+// ReduceWhile folds the slice from left to right, starting from initial.
+// fn returns the new accumulator and whether to continue; once it returns false
+// the accumulator it returned is the result and the remaining elements are skipped.
+func ReduceWhile[T, R any](slice []T, initial R, fn func(acc R, item T) (R, bool)) R {
+	acc := initial
+	for _, item := range slice {
+		var more bool
+		acc, more = fn(acc, item)
+		if !more {
+			break
+		}
+	}
+
+	return acc
+}
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// TopK returns the k most frequent elements paired with their counts, sorted by
+// count in descending order. Ties are broken by first appearance in the slice.
+// If k exceeds the number of distinct elements, all of them are returned.
+// Returns nil if k is not positive or the slice is empty.
+func TopK[T comparable](slice []T, k int) []Pair[T, int] {
+	if k <= 0 || len(slice) == 0 {
+		return nil
+	}
+
+	counts := make(map[T]int)
+	var order []T
+	for _, item := range slice {
+		if counts[item] == 0 {
+			order = append(order, item)
+		}
+		counts[item]++
+	}
+
+	result := make([]Pair[T, int], len(order))
+	for i, item := range order {
+		result[i] = Pair[T, int]{First: item, Second: counts[item]}
+	}
+
+	// Stable sort keeps first-appearance order among equal counts
+	slices.SortStableFunc(result, func(a, b Pair[T, int]) int {
+		return cmp.Compare(b.Second, a.Second)
+	})
+
+	if k < len(result) {
+		result = result[:k]
+	}
+
+	return result
+}
+
+// IndexMap returns a map from each element to the index of its first occurrence
+// in the slice, allowing O(1) position lookups once the map is built.
+// When an element appears more than once, the first index is kept.
+func IndexMap[T comparable](slice []T) map[T]int {
+	result := make(map[T]int, len(slice))
+
+	for i, item := range slice {
+		if _, ok := result[item]; !ok {
+			result[item] = i
+		}
+	}
+
+	return result
+}
+
+// Associate builds a map from the slice, using fn to produce the key and value
+// for each element. When several elements produce the same key, the last one wins.
+func Associate[T any, K comparable, V any](slice []T, fn func(T) (K, V)) map[K]V {
+	result := make(map[K]V, len(slice))
+
+	for _, item := range slice {
+		k, v := fn(item)
+		result[k] = v
+	}
+
+	return result
+}
+
+// KeyBy builds a lookup map from each element's key to the element itself, such
+// as an ID-to-record index. When several elements share a key, the last one wins.
+func KeyBy[T any, K comparable](slice []T, keyFn func(T) K) map[K]T {
+	return Associate(slice, func(item T) (K, T) { return keyFn(item), item })
+}
+
+// Contains reports whether target is present in the slice.
+func Contains[T comparable](slice []T, target T) bool {
+	return IndexOf(slice, target) >= 0
+}
+
+// IndexOf returns the index of the first occurrence of target in the slice,
+// or -1 if it is not present.
+func IndexOf[T comparable](slice []T, target T) int {
+	return IndexFunc(slice, func(item T) bool { return item == target })
+}
+
+// ContainsFunc reports whether any element satisfies the predicate.
+// Unlike Contains, it works for element types that are not comparable.
+func ContainsFunc[T any](slice []T, predicate func(T) bool) bool {
+	return IndexFunc(slice, predicate) >= 0
+}
+
+// IndexFunc returns the index of the first element that satisfies the predicate,
+// or -1 if none does.
+func IndexFunc[T any](slice []T, predicate func(T) bool) int {
+	for i, item := range slice {
+		if predicate(item) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Find returns the first element that satisfies pred. The bool is false, with the
+// zero value of T, if no element matches.
+func Find[T any](slice []T, pred func(T) bool) (T, bool) {
+	if i := IndexFunc(slice, pred); i >= 0 {
+		return slice[i], true
+	}
+
+	var zero T
+	return zero, false
+}
+
+// FindLast returns the last element that satisfies pred. The bool is false, with
+// the zero value of T, if no element matches.
+func FindLast[T any](slice []T, pred func(T) bool) (T, bool) {
+	for i := len(slice) - 1; i >= 0; i-- {
+		if pred(slice[i]) {
+			return slice[i], true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Any reports whether at least one element satisfies pred, stopping at the first
+// match. Any of an empty slice is false.
+func Any[T any](slice []T, pred func(T) bool) bool {
+	return IndexFunc(slice, pred) >= 0
+}
+
+// All reports whether every element satisfies pred, stopping at the first element
+// that does not. All of an empty slice is true (vacuous truth).
+func All[T any](slice []T, pred func(T) bool) bool {
+	return !Any(slice, func(item T) bool { return !pred(item) })
+}
+
+// Equal reports whether a and b have the same length and equal elements in the
+// same order. A nil slice equals an empty one.
+func Equal[T comparable](a, b []T) bool {
+	return slices.Equal(a, b)
+}
+
+// ElementsMatch reports whether a and b contain the same elements with the same
+// multiplicities, in any order, such as results that arrive in nondeterministic
+// order. It counts elements in a map rather than sorting, so it runs in linear time.
+func ElementsMatch[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[T]int, len(a))
+	for _, item := range a {
+		counts[item]++
+	}
+	for _, item := range b {
+		if counts[item] == 0 {
+			return false
+		}
+		counts[item]--
+	}
+
+	return true
+}
+
+// AutoConcurrency can be passed to the WithConcurrency methods of the concurrent
+// handlers to size the worker pool to runtime.GOMAXPROCS(0) at Execute time, which
+// suits CPU-bound functions. Any concurrency of zero or less behaves the same way.
+const AutoConcurrency = 0
+
+// ErrCancelled is returned, wrapping the context's error, when a concurrent
+// operation ends because its context was cancelled or its deadline passed.
+var ErrCancelled = errors.New("execution cancelled")
+
+// IndexedError is the failure of the input item at Index.
+type IndexedError struct {
+	Index int
+	Err   error
+}
+
+// MapError is returned by MapConcurrent and ForEachConcurrent when one or more items
+// fail, recording which input index produced each error so that callers can, for
+// example, retry only the failed inputs. Use errors.As to extract it; if the context
+// also ended, it is joined with the ErrCancelled error.
+type MapError struct {
+	// Errors holds the item failures in ascending index order.
+	Errors []IndexedError
+}
+
+// Error lists the failures in ascending index order, one per line, each prefixed
+// with its index, such as "item 3: connection refused".
+func (e *MapError) Error() string {
+	lines := Map(e.Errors, func(ie IndexedError) string {
+		return fmt.Sprintf("item %d: %v", ie.Index, ie.Err)
+	})
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the item errors so that errors.Is and errors.As can match them.
+func (e *MapError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, ie := range e.Errors {
+		errs[i] = ie.Err
 	}
+	return errs
+}
 
-	return result
+// Indices returns the indices of the failed items in ascending order.
+func (e *MapError) Indices() []int {
+	return Map(e.Errors, func(ie IndexedError) int { return ie.Index })
 }
 
 // MapConcurrentHandler provides fluent configuration for concurrent map operations.
 type MapConcurrentHandler[T, R any] struct {
-	mapFunc     func(context.Context, T) (R, error)
-	concurrency int
-	stopOnError bool
+	mapFunc         func(context.Context, T) (R, error)
+	concurrency     int
+	stopOnError     bool
+	orderedCallback func(index int, value R)
+	validate        func(T) error
+	resultHook      func(index int, item T, result R) R
+	maxDuration     time.Duration
+	rateLimit       float64
+	progress        func(completed, total int)
 }
 
 // WithConcurrency sets the maximum number of concurrent operations.
-// Defaults to 8 if not specified.
+// Defaults to 8 if not specified; AutoConcurrency uses runtime.GOMAXPROCS(0).
 func (h *MapConcurrentHandler[T, R]) WithConcurrency(n int) *MapConcurrentHandler[T, R] {
 	h.concurrency = n
 	return h
@@ -90,12 +1006,53 @@ func (h *MapConcurrentHandler[T, R]) WithConcurrency(n int) *MapConcurrentHandle
 
 // WithStopOnError configures whether to stop processing on first error (true)
 // or collect all errors and continue processing (false).
-// Defaults to true (stop on first error).
+// Defaults to true (stop on first error). When stopping, the context passed to
+// in-flight calls of mapFunc is cancelled so they can abort promptly.
 func (h *MapConcurrentHandler[T, R]) WithStopOnError(stop bool) *MapConcurrentHandler[T, R] {
 	h.stopOnError = stop
 	return h
 }
 
+// WithOrderedCallback streams each successful result to fn in input order instead
+// of collecting results into a slice. Results that complete out of order are
+// buffered until every earlier item has finished, and failed items are skipped.
+// At most twice the worker count of items are dispatched past the earliest
+// unfinished one, so a slow item holds back new work rather than growing the buffer.
+// Calls to fn are serialized, so fn does not need to be safe for concurrent use.
+// When a callback is configured, Execute returns a nil results slice.
+func (h *MapConcurrentHandler[T, R]) WithOrderedCallback(fn func(index int, value R)) *MapConcurrentHandler[T, R] {
+	h.orderedCallback = fn
+	return h
+}
+
+// WithValidate configures a validation function that is run sequentially over every
+// input element before any worker starts. If any element fails validation, Execute
+// returns the combined validation errors without calling the mapping function at all,
+// so side-effecting batches are never partially applied.
+func (h *MapConcurrentHandler[T, R]) WithValidate(fn func(T) error) *MapConcurrentHandler[T, R] {
+	h.validate = fn
+	return h
+}
+
+// WithResultHook configures a function that post-processes each successful result
+// before it is stored, for example to enrich or log it. The hook receives the item's
+// index, the input item and the mapped result, and its return value becomes the
+// stored result. It runs on the worker goroutines, so it must be safe for concurrent use.
+func (h *MapConcurrentHandler[T, R]) WithResultHook(fn func(index int, item T, result R) R) *MapConcurrentHandler[T, R] {
+	h.resultHook = fn
+	return h
+}
+
+// WithMaxDuration limits the wall-clock time of the whole batch. Once d has elapsed,
+// no further items are started, the context passed to in-flight mapping functions
+// is cancelled, and the returned error wraps ErrCancelled and
+// context.DeadlineExceeded. Items that completed before the deadline are still
+// available through ExecuteOptional. A value of 0 or less disables the limit.
+func (h *MapConcurrentHandler[T, R]) WithMaxDuration(d time.Duration) *MapConcurrentHandler[T, R] {
+	h.maxDuration = d
+	return h
+}
+
 // mapConcurrentJob represents a work item for the worker pool
 type mapConcurrentJob[T any] struct {
 	index int
@@ -109,22 +1066,233 @@ type mapConcurrentResult[R any] struct {
 	err   error
 }
 
+// Optional holds a value that may be absent. Present is false when the value
+// was never computed, distinguishing it from a computed zero value.
+type Optional[T any] struct {
+	Value   T
+	Present bool
+}
+
+// WithRateLimit limits how often mapFunc is started across all workers to at
+// most perSecond calls per second, independently of the concurrency, for
+// calling rate-limited APIs. Calls are spaced evenly with no bursting. Workers
+// waiting for their turn stop when the context ends. A value <= 0 disables
+// rate limiting, which is the default.
+func (h *MapConcurrentHandler[T, R]) WithRateLimit(perSecond float64) *MapConcurrentHandler[T, R] {
+	h.rateLimit = perSecond
+	return h
+}
+
+// WithProgress configures a function that is called each time an item finishes,
+// successfully or not, with the number of items completed so far and the total,
+// for reporting progress of long-running operations. Calls are serialized, so fn
+// need not be safe for concurrent use, but it runs on the workers and should
+// return quickly. Completion order is nondeterministic; only the counts are
+// meaningful.
+func (h *MapConcurrentHandler[T, R]) WithProgress(fn func(completed, total int)) *MapConcurrentHandler[T, R] {
+	h.progress = fn
+	return h
+}
+
 // Execute runs the concurrent map operation on the provided slice.
 // Returns a slice of results preserving input order and any errors encountered.
+// On error, the results are nil when stopping on the first error; with
+// WithStopOnError(false) the partially populated results are returned alongside
+// the error, with the zero value of R at every index that failed.
 func (h *MapConcurrentHandler[T, R]) Execute(ctx context.Context, items []T) ([]R, error) {
 	if len(items) == 0 {
 		return nil, nil
 	}
 
-	// Determine actual number of workers (min of concurrency and items length)
-	numWorkers := h.concurrency
-	if n := len(items); n < numWorkers {
-		numWorkers = n
+	// Stream results to the ordered callback instead of collecting them
+	if h.orderedCallback != nil {
+		emitter := newOrderedEmitter(h.orderedCallback, h.orderedWindow(len(items)))
+		return nil, h.run(ctx, items, emitter.slots, emitter.emit)
 	}
 
 	// Pre-allocate mapConcurrentResult items to preserve ordering
 	results := make([]R, len(items))
-	errs := make([]error, len(items)+1)
+	err := h.run(ctx, items, nil, func(r mapConcurrentResult[R]) {
+		if r.err == nil {
+			results[r.index] = r.value
+		}
+	})
+	if err != nil && h.stopOnError {
+		return nil, err
+	}
+
+	return results, err
+}
+
+// ExecuteOptional runs the concurrent map operation like Execute, but reports
+// each result as an Optional so that items which failed or were never processed
+// (Present false) can be told apart from items that legitimately mapped to the
+// zero value (Present true). The results slice is returned even when err is non-nil.
+func (h *MapConcurrentHandler[T, R]) ExecuteOptional(ctx context.Context, items []T) ([]Optional[R], error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	results := make([]Optional[R], len(items))
+	err := h.run(ctx, items, nil, func(r mapConcurrentResult[R]) {
+		if r.err == nil {
+			results[r.index] = Optional[R]{Value: r.value, Present: true}
+		}
+	})
+
+	return results, err
+}
+
+// ExecuteAll runs the concurrent map operation on every item and returns the
+// results and a per-index error slice of the same length as items, without joining
+// the errors, for callers that feed partial successes into a downstream pipeline.
+// errs[i] is non-nil exactly when item i failed, in which case results[i] is the
+// zero value of R. The stop-on-error setting is ignored; only the end of ctx stops
+// the run early, and items that never ran then report an error wrapping
+// ErrCancelled. If validation fails, no item runs and every item reports the
+// validation error. Returns nil, nil for empty input.
+func (h *MapConcurrentHandler[T, R]) ExecuteAll(ctx context.Context, items []T) ([]R, []error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	all := *h
+	all.stopOnError = false
+
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	processed := make([]bool, len(items))
+	err := all.run(ctx, items, nil, func(r mapConcurrentResult[R]) {
+		processed[r.index] = true
+		if r.err != nil {
+			errs[r.index] = r.err
+			return
+		}
+		results[r.index] = r.value
+	})
+
+	if err != nil {
+		notRun := notProcessedError(err)
+		for i, ok := range processed {
+			if !ok {
+				errs[i] = notRun
+			}
+		}
+	}
+
+	return results, errs
+}
+
+// ExecuteToWriter runs the concurrent map operation and passes each successful
+// result to write in input order as soon as it and all earlier items have completed,
+// for piping results into an ordered sink such as a file or HTTP response.
+// write is called from the worker goroutines, one call at a time, so a slow sink
+// holds back the workers. As with WithOrderedCallback, at most twice the worker
+// count of results are buffered ahead of the next write. If write returns an error, remaining work is cancelled,
+// no further writes are made and that error is returned.
+func (h *MapConcurrentHandler[T, R]) ExecuteToWriter(ctx context.Context, items []T, write func(R) error) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// writeErr is only accessed under the emitter's lock until run returns
+	var writeErr error
+	emitter := newOrderedEmitter(func(_ int, value R) {
+		if writeErr != nil {
+			return
+		}
+		if err := write(value); err != nil {
+			writeErr = err
+			cancel()
+		}
+	}, h.orderedWindow(len(items)))
+
+	err := h.run(ctx, items, emitter.slots, emitter.emit)
+	if writeErr != nil {
+		return writeErr
+	}
+
+	return err
+}
+
+// run processes items on the worker pool, passing every completed item to
+// onResult. onResult is called concurrently from the workers, at most once per
+// index. slots optionally bounds dispatch, see runPool. Returns the joined errors
+// of failed items and the parent context.
+func (h *MapConcurrentHandler[T, R]) run(ctx context.Context, items []T, slots chan struct{}, onResult func(mapConcurrentResult[R])) error {
+	if h.mapFunc == nil {
+		return errors.New("mapFunc must not be nil")
+	}
+
+	if err := h.validateItems(items); err != nil {
+		return err
+	}
+
+	// Bound the whole batch; the deadline is reported like a parent cancellation
+	if h.maxDuration > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, h.maxDuration)
+		defer cancelTimeout()
+	}
+
+	var limiter *rateLimiter
+	if h.rateLimit > 0 {
+		limiter = newRateLimiter(h.rateLimit)
+	}
+
+	var progressMu sync.Mutex
+	completed := 0
+
+	errs := runPool(ctx, items, h.concurrency, h.stopOnError, slots, func(ctx context.Context, index int, item T) error {
+		if limiter != nil {
+			if err := limiter.wait(ctx); err != nil {
+				return err
+			}
+		}
+
+		v, err := h.mapFunc(ctx, item)
+		if err == nil && h.resultHook != nil {
+			v = h.resultHook(index, item, v)
+		}
+		onResult(mapConcurrentResult[R]{index: index, value: v, err: err})
+
+		if h.progress != nil {
+			progressMu.Lock()
+			completed++
+			h.progress(completed, len(items))
+			progressMu.Unlock()
+		}
+
+		return err
+	})
+
+	return joinErrors(ctx, errs)
+}
+
+// runPool calls fn for every item on a pool of at most concurrency workers and
+// returns the error reported for each index. fn receives a context derived from
+// ctx that is cancelled when the pool shuts down. If stopOnError is set, the first
+// failure cancels that context, so in-flight items can abort promptly, and stops
+// workers from taking further items; items that were never started or that were
+// aborted this way have no error recorded.
+//
+// If slots is non-nil, an item is only dispatched once a slot can be taken from it;
+// the caller releases slots as items are consumed, bounding how far dispatch runs
+// ahead.
+func runPool[T any](
+	ctx context.Context,
+	items []T,
+	concurrency int,
+	stopOnError bool,
+	slots chan struct{},
+	fn func(ctx context.Context, index int, item T) error,
+) []error {
+	numWorkers := workerCount(concurrency, len(items))
+
+	errs := make([]error, len(items))
 
 	// Create channels for mapConcurrentJob distribution and mapConcurrentResult collection
 	jobs := make(chan mapConcurrentJob[T], len(items))
@@ -145,15 +1313,17 @@ func (h *MapConcurrentHandler[T, R]) Execute(ctx context.Context, items []T) ([]
 				if !ok {
 					return
 				}
-				v, err := h.mapFunc(ctx, item.value)
-				if err != nil {
+				if err := fn(child, item.index, item.value); err != nil {
+					// Items aborted because a sibling failed only report the pool's
+					// own cancellation; keep the original failure alone.
+					if ctx.Err() == nil && errors.Is(err, context.Canceled) && child.Err() != nil {
+						return
+					}
 					errs[item.index] = err
-					if h.stopOnError {
+					if stopOnError {
 						cancel()
 						return
 					}
-				} else {
-					results[item.index] = v
 				}
 			}
 		}
@@ -168,6 +1338,13 @@ func (h *MapConcurrentHandler[T, R]) Execute(ctx context.Context, items []T) ([]
 	go func() {
 		defer close(jobs)
 		for i, item := range items {
+			if slots != nil {
+				select {
+				case slots <- struct{}{}:
+				case <-child.Done():
+					return
+				}
+			}
 			select {
 			case jobs <- mapConcurrentJob[T]{index: i, value: item}:
 			case <-child.Done():
@@ -178,12 +1355,170 @@ func (h *MapConcurrentHandler[T, R]) Execute(ctx context.Context, items []T) ([]
 
 	// wait for all workers to complete
 	wg.Wait()
-	errs = append(errs, ctx.Err()) // ctx.Err is nil if no error
-	if err := errors.Join(errs...); err != nil {
-		return nil, err
+	return errs
+}
+
+// workerCount resolves a concurrency setting to the number of workers started for
+// n items (min of concurrency and n, with AutoConcurrency meaning GOMAXPROCS).
+func workerCount(concurrency, n int) int {
+	if concurrency <= AutoConcurrency {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	return min(concurrency, n)
+}
+
+// joinErrors collects per-item errors into a MapError, joined with a single
+// ErrCancelled error if the parent context ended. Item errors that only report that
+// same context error are folded into the cancellation error rather than repeated
+// for every item. Returns nil if there were no errors.
+func joinErrors(ctx context.Context, errs []error) error {
+	ctxErr := ctx.Err()
+
+	var itemErrs []IndexedError
+	for i, err := range errs {
+		if err != nil && (ctxErr == nil || !errors.Is(err, ctxErr)) {
+			itemErrs = append(itemErrs, IndexedError{Index: i, Err: err})
+		}
+	}
+
+	var mapErr error
+	if len(itemErrs) > 0 {
+		mapErr = &MapError{Errors: itemErrs}
+	}
+	if ctxErr == nil {
+		return mapErr
+	}
+
+	cancelErr := fmt.Errorf("%w: %w", ErrCancelled, ctxErr)
+	if mapErr == nil {
+		return cancelErr
+	}
+
+	return errors.Join(mapErr, cancelErr)
+}
+
+// notProcessedError returns the error to report for items that never produced a
+// result: the cancellation error that joinErrors places after the item errors, or
+// err itself when it did not come from the worker pool, such as a validation error.
+func notProcessedError(err error) error {
+	if _, ok := err.(*MapError); ok {
+		return err
+	}
+
+	var mapErr *MapError
+	if joined, ok := err.(interface{ Unwrap() []error }); ok && errors.As(err, &mapErr) {
+		errs := joined.Unwrap()
+		return errs[len(errs)-1]
+	}
+
+	return err
+}
+
+// validateItems runs the configured validation function over every item and
+// returns the joined errors, each annotated with the item's index.
+func (h *MapConcurrentHandler[T, R]) validateItems(items []T) error {
+	if h.validate == nil {
+		return nil
+	}
+
+	var errs []error
+	for i, item := range items {
+		if err := h.validate(item); err != nil {
+			errs = append(errs, fmt.Errorf("invalid item %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// orderedWindowFactor is how many items per worker may be dispatched past the
+// next index awaited by an orderedEmitter.
+const orderedWindowFactor = 2
+
+// orderedWindow returns the dispatch window used for ordered delivery of n items.
+func (h *MapConcurrentHandler[T, R]) orderedWindow(n int) int {
+	return workerCount(h.concurrency, n) * orderedWindowFactor
+}
+
+// orderedEmitter delivers results to a callback in index order, buffering
+// results that complete ahead of earlier indices. Passing slots to runPool bounds
+// the buffer: a slot is released each time the next index is delivered, so at
+// most window items are ever dispatched but not yet delivered.
+type orderedEmitter[R any] struct {
+	mu      sync.Mutex
+	next    int
+	pending map[int]mapConcurrentResult[R]
+	slots   chan struct{}
+	fn      func(index int, value R)
+}
+
+func newOrderedEmitter[R any](fn func(index int, value R), window int) *orderedEmitter[R] {
+	return &orderedEmitter[R]{
+		pending: make(map[int]mapConcurrentResult[R]),
+		slots:   make(chan struct{}, window),
+		fn:      fn,
+	}
+}
+
+// emit records a completed result and flushes every result that is now contiguous
+// with the last delivered index. Failed results advance the position without
+// invoking the callback.
+func (e *orderedEmitter[R]) emit(r mapConcurrentResult[R]) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.pending[r.index] = r
+	for {
+		next, ok := e.pending[e.next]
+		if !ok {
+			return
+		}
+		delete(e.pending, e.next)
+		e.next++
+		<-e.slots
+
+		if next.err == nil {
+			e.fn(next.index, next.value)
+		}
+	}
+}
+
+// rateLimiter spaces calls evenly at a fixed rate, shared by all workers of a run.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(perSecond float64) *rateLimiter {
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// wait reserves the next free slot and blocks until it arrives or ctx ends.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
 	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
 
-	return results, nil
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // MapConcurrent creates a new concurrent map handler with the given mapping function.
@@ -196,3 +1531,244 @@ func MapConcurrent[T, R any](mapFunc func(context.Context, T) (R, error)) *MapCo
 		stopOnError: true, // Default behavior: stop on first error
 	}
 }
+
+// ForEachConcurrentHandler provides fluent configuration for running a side effect
+// concurrently over every element of a slice.
+type ForEachConcurrentHandler[T any] struct {
+	fn          func(context.Context, int, T) error
+	concurrency int
+	stopOnError bool
+}
+
+// WithConcurrency sets the maximum number of concurrent calls.
+// Defaults to 8 if not specified; AutoConcurrency uses runtime.GOMAXPROCS(0).
+func (h *ForEachConcurrentHandler[T]) WithConcurrency(n int) *ForEachConcurrentHandler[T] {
+	h.concurrency = n
+	return h
+}
+
+// WithStopOnError configures whether to stop processing on the first error.
+// If true (default), no further elements are started after an error.
+// If false, every element is processed and all errors are returned together.
+func (h *ForEachConcurrentHandler[T]) WithStopOnError(stop bool) *ForEachConcurrentHandler[T] {
+	h.stopOnError = stop
+	return h
+}
+
+// Execute calls the function for every item on the shared worker pool used by
+// MapConcurrent. Errors are reported like MapConcurrentHandler.Execute, including
+// an error wrapping ErrCancelled if ctx ends before all items are processed.
+func (h *ForEachConcurrentHandler[T]) Execute(ctx context.Context, items []T) error {
+	if h.fn == nil {
+		return errors.New("fn must not be nil")
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	return joinErrors(ctx, runPool(ctx, items, h.concurrency, h.stopOnError, nil, h.fn))
+}
+
+// ForEachConcurrentIndexed creates a handler that calls fn concurrently for every
+// element, passing the element's index alongside it, for side effects that depend on
+// position such as writing to a destination sharded by index.
+// Returns a handler that can be configured with fluent methods before execution.
+func ForEachConcurrentIndexed[T any](fn func(ctx context.Context, index int, item T) error) *ForEachConcurrentHandler[T] {
+	return &ForEachConcurrentHandler[T]{
+		fn:          fn,
+		concurrency: 8,    // Default concurrency level
+		stopOnError: true, // Default behavior: stop on first error
+	}
+}
+
+// ForEachConcurrent creates a handler that calls fn concurrently for every element
+// purely for its side effects, such as uploads or webhooks, without allocating a
+// results slice. It shares its worker pool and options with MapConcurrent.
+// Returns a handler that can be configured with fluent methods before execution.
+func ForEachConcurrent[T any](fn func(ctx context.Context, item T) error) *ForEachConcurrentHandler[T] {
+	if fn == nil {
+		return ForEachConcurrentIndexed[T](nil)
+	}
+
+	return ForEachConcurrentIndexed(func(ctx context.Context, _ int, item T) error {
+		return fn(ctx, item)
+	})
+}
+
+// MapBatchConcurrentHandler provides fluent configuration for concurrent batched
+// map operations.
+type MapBatchConcurrentHandler[T, R any] struct {
+	batchFunc   func(context.Context, []T) ([]R, error)
+	batchSize   int
+	concurrency int
+	stopOnError bool
+}
+
+// WithBatchSize sets the number of items passed to each call of the batch function.
+// The final batch holds the remainder. Defaults to 100 if not specified.
+func (h *MapBatchConcurrentHandler[T, R]) WithBatchSize(n int) *MapBatchConcurrentHandler[T, R] {
+	h.batchSize = n
+	return h
+}
+
+// WithConcurrency sets the maximum number of batches processed concurrently.
+// Defaults to 8 if not specified; AutoConcurrency uses runtime.GOMAXPROCS(0).
+func (h *MapBatchConcurrentHandler[T, R]) WithConcurrency(n int) *MapBatchConcurrentHandler[T, R] {
+	h.concurrency = n
+	return h
+}
+
+// WithStopOnError configures whether to stop processing on the first failed batch.
+// If true (default), Execute returns nil results on error. If false, every batch is
+// processed and the results of failed batches are left as zero values.
+func (h *MapBatchConcurrentHandler[T, R]) WithStopOnError(stop bool) *MapBatchConcurrentHandler[T, R] {
+	h.stopOnError = stop
+	return h
+}
+
+// Execute splits the items into batches, runs the batch function on the batches
+// concurrently and returns the flattened results in input order. A batch whose
+// result length differs from its input length fails. Failures are reported like
+// MapConcurrentHandler.Execute, except that MapError indices refer to batches.
+func (h *MapBatchConcurrentHandler[T, R]) Execute(ctx context.Context, items []T) ([]R, error) {
+	if h.batchFunc == nil {
+		return nil, errors.New("batchFunc must not be nil")
+	}
+
+	if h.batchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", h.batchSize)
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	batches := ChunkStride(items, h.batchSize, h.batchSize)
+	batchResults, err := MapConcurrent(func(ctx context.Context, batch []T) ([]R, error) {
+		results, err := h.batchFunc(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) != len(batch) {
+			return nil, fmt.Errorf("batch returned %d results for %d items", len(results), len(batch))
+		}
+		return results, nil
+	}).
+		WithConcurrency(h.concurrency).
+		WithStopOnError(h.stopOnError).
+		Execute(ctx, batches)
+	if err != nil && h.stopOnError {
+		return nil, err
+	}
+
+	results := make([]R, len(items))
+	for i, batch := range batchResults {
+		copy(results[i*h.batchSize:], batch)
+	}
+
+	return results, err
+}
+
+// MapBatchConcurrent creates a new handler that maps items in batches, for APIs
+// that accept bulk requests. The batch function receives consecutive items and must
+// return exactly one result per item, in the same order.
+// Returns a handler that can be configured with fluent methods before execution.
+func MapBatchConcurrent[T, R any](batchFunc func(context.Context, []T) ([]R, error)) *MapBatchConcurrentHandler[T, R] {
+	return &MapBatchConcurrentHandler[T, R]{
+		batchFunc:   batchFunc,
+		batchSize:   100,  // Default batch size
+		concurrency: 8,    // Default concurrency level
+		stopOnError: true, // Default behavior: stop on first error
+	}
+}
+
+// ChunkConcurrentHandler provides fluent configuration for running a side effect
+// concurrently over consecutive chunks of a slice.
+type ChunkConcurrentHandler[T any] struct {
+	chunkFunc   func(context.Context, []T) error
+	chunkSize   int
+	concurrency int
+	stopOnError bool
+}
+
+// WithChunkSize sets the number of items passed to each call of the chunk function.
+// The final chunk holds the remainder. Defaults to 100 if not specified.
+func (h *ChunkConcurrentHandler[T]) WithChunkSize(n int) *ChunkConcurrentHandler[T] {
+	h.chunkSize = n
+	return h
+}
+
+// WithConcurrency sets the maximum number of chunks processed concurrently.
+// Defaults to 8 if not specified; AutoConcurrency uses runtime.GOMAXPROCS(0).
+func (h *ChunkConcurrentHandler[T]) WithConcurrency(n int) *ChunkConcurrentHandler[T] {
+	h.concurrency = n
+	return h
+}
+
+// WithStopOnError configures whether to stop processing on the first failed chunk.
+// If true (default), no further chunks are started after an error.
+// If false, every chunk is processed and all errors are returned together.
+func (h *ChunkConcurrentHandler[T]) WithStopOnError(stop bool) *ChunkConcurrentHandler[T] {
+	h.stopOnError = stop
+	return h
+}
+
+// Execute splits the items into chunks and runs the chunk function on the chunks
+// concurrently. Failures are reported like ForEachConcurrentHandler.Execute, except
+// that MapError indices refer to chunks.
+func (h *ChunkConcurrentHandler[T]) Execute(ctx context.Context, items []T) error {
+	if h.chunkFunc == nil {
+		return errors.New("chunkFunc must not be nil")
+	}
+
+	if h.chunkSize <= 0 {
+		return fmt.Errorf("chunk size must be positive, got %d", h.chunkSize)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	return ForEachConcurrent(h.chunkFunc).
+		WithConcurrency(h.concurrency).
+		WithStopOnError(h.stopOnError).
+		Execute(ctx, ChunkStride(items, h.chunkSize, h.chunkSize))
+}
+
+// ChunkConcurrent creates a new handler that calls chunkFunc concurrently on
+// consecutive chunks of the input, for bulk side effects such as one INSERT
+// statement per chunk. Unlike MapBatchConcurrent, no results are collected.
+// Returns a handler that can be configured with fluent methods before execution.
+func ChunkConcurrent[T any](chunkFunc func(context.Context, []T) error) *ChunkConcurrentHandler[T] {
+	return &ChunkConcurrentHandler[T]{
+		chunkFunc:   chunkFunc,
+		chunkSize:   100,  // Default chunk size
+		concurrency: 8,    // Default concurrency level
+		stopOnError: true, // Default behavior: stop on first error
+	}
+}
+
+// MapReduce maps every element concurrently with mapFn using the default MapConcurrent
+// worker pool, then folds the mapped values in input order with reduceFn, starting
+// from initial. If any mapping fails, the map phase stops and initial is returned
+// along with the error.
+func MapReduce[T, M, R any](
+	ctx context.Context,
+	slice []T,
+	mapFn func(context.Context, T) (M, error),
+	reduceFn func(R, M) R,
+	initial R,
+) (R, error) {
+	mapped, err := MapConcurrent(mapFn).Execute(ctx, slice)
+	if err != nil {
+		return initial, err
+	}
+
+	acc := initial
+	for _, m := range mapped {
+		acc = reduceFn(acc, m)
+	}
+
+	return acc, nil
+}
@@ -6,8 +6,12 @@ package slicex
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"runtime"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -60,6 +64,87 @@ func TestUniqueStrings(t *testing.T) {
 	}
 }
 
+func TestUniqueHashable(t *testing.T) {
+	type tagged struct {
+		Name string
+		Tags []string
+	}
+
+	input := []tagged{
+		{"a", []string{"x", "y"}},
+		{"b", []string{"x"}},
+		{"a", []string{"x", "y"}},
+		{"a", []string{"y", "x"}},
+		{"b", []string{"x"}},
+	}
+	hash := func(v tagged) string {
+		return v.Name + "|" + strings.Join(v.Tags, ",")
+	}
+
+	expected := []tagged{
+		{"a", []string{"x", "y"}},
+		{"b", []string{"x"}},
+		{"a", []string{"y", "x"}},
+	}
+	result := UniqueHashable(input, hash)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("UniqueHashable(%v) = %v, expected %v", input, result, expected)
+	}
+
+	if result := UniqueHashable([]tagged{}, hash); result != nil {
+		t.Errorf("UniqueHashable([]) = %v, expected nil", result)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	tests := map[string]struct {
+		input    []string
+		expected []string
+	}{
+		"adjacent duplicates collapse": {
+			input:    []string{"a", "a", "b", "b", "b", "c"},
+			expected: []string{"a", "b", "c"},
+		},
+		"separated duplicates are preserved": {
+			input:    []string{"up", "down", "up", "up", "down"},
+			expected: []string{"up", "down", "up", "down"},
+		},
+		"no duplicates": {
+			input:    []string{"a", "b"},
+			expected: []string{"a", "b"},
+		},
+		"empty slice": {
+			input:    []string{},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			original := slices.Clone(tt.input)
+			result := Compact(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Compact(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+			if !reflect.DeepEqual(tt.input, original) {
+				t.Errorf("Compact modified its input: %v", tt.input)
+			}
+		})
+	}
+}
+
+func TestCompactFunc(t *testing.T) {
+	input := [][]int{{1}, {2}, {3, 4}, {5}, {6, 7}, {8, 9}}
+	sameLen := func(a, b []int) bool { return len(a) == len(b) }
+
+	result := CompactFunc(input, sameLen)
+	expected := [][]int{{1}, {3, 4}, {5}, {6, 7}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("CompactFunc(%v) = %v, expected %v", input, result, expected)
+	}
+}
+
 func TestFilterNonZero(t *testing.T) {
 	tests := map[string]struct {
 		input    []int
@@ -107,383 +192,3261 @@ func TestFilterNonZeroStrings(t *testing.T) {
 	}
 }
 
-func TestMap(t *testing.T) {
-	t.Run("int to string", func(t *testing.T) {
-		input := []int{1, 2, 3, 4}
-		expected := []string{"1", "2", "3", "4"}
-		result := Map(input, func(i int) string {
-			return strconv.Itoa(i)
-		})
+func TestFilter(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
 
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Map(%v, intToString) = %v, expected %v", input, result, expected)
-		}
-	})
+	tests := map[string]struct {
+		input    []int
+		expected []int
+	}{
+		"keeps matching in order": {
+			input:    []int{1, 2, 3, 4, 5, 6},
+			expected: []int{2, 4, 6},
+		},
+		"all kept": {
+			input:    []int{2, 4},
+			expected: []int{2, 4},
+		},
+		"all filtered": {
+			input:    []int{1, 3, 5},
+			expected: nil,
+		},
+		"empty slice": {
+			input:    []int{},
+			expected: nil,
+		},
+		"nil slice": {
+			input:    nil,
+			expected: nil,
+		},
+	}
 
-	t.Run("string to length", func(t *testing.T) {
-		input := []string{"hello", "world", "go", "test"}
-		expected := []int{5, 5, 2, 4}
-		result := Map(input, func(s string) int {
-			return len(s)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Filter(tt.input, isEven)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Filter(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
 		})
+	}
+}
 
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Map(%v, stringToLength) = %v, expected %v", input, result, expected)
-		}
-	})
-
-	t.Run("empty slice", func(t *testing.T) {
-		input := []int{}
-		expected := []string(nil)
-		result := Map(input, func(i int) string {
-			return strconv.Itoa(i)
-		})
+func TestReject(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
 
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Map(%v, intToString) = %v, expected %v", input, result, expected)
-		}
-	})
+	tests := map[string]struct {
+		input    []int
+		expected []int
+	}{
+		"drops matching in order": {
+			input:    []int{1, 2, 3, 4, 5, 6},
+			expected: []int{1, 3, 5},
+		},
+		"all rejected": {
+			input:    []int{2, 4},
+			expected: nil,
+		},
+		"empty slice": {
+			input:    []int{},
+			expected: nil,
+		},
+	}
 
-	t.Run("square numbers", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5}
-		expected := []int{1, 4, 9, 16, 25}
-		result := Map(input, func(i int) int {
-			return i * i
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Reject(tt.input, isEven)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Reject(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
 		})
-
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Map(%v, square) = %v, expected %v", input, result, expected)
-		}
-	})
+	}
 }
 
-func TestGroup(t *testing.T) {
-	t.Run("group by string length", func(t *testing.T) {
-		input := []string{"hello", "world", "go", "test", "a", "b"}
-		result := Group(input, func(s string) int {
-			return len(s)
-		})
-
-		expected := map[int][]string{
-			1: {"a", "b"},
-			2: {"go"},
-			4: {"test"},
-			5: {"hello", "world"},
-		}
+func TestIndexOf(t *testing.T) {
+	tests := map[string]struct {
+		input    []string
+		target   string
+		expected int
+	}{
+		"empty slice": {
+			input:    []string{},
+			target:   "a",
+			expected: -1,
+		},
+		"first element": {
+			input:    []string{"a", "b", "c"},
+			target:   "a",
+			expected: 0,
+		},
+		"last element": {
+			input:    []string{"a", "b", "c"},
+			target:   "c",
+			expected: 2,
+		},
+		"duplicates return first occurrence": {
+			input:    []string{"x", "b", "y", "b"},
+			target:   "b",
+			expected: 1,
+		},
+		"absent": {
+			input:    []string{"a", "b"},
+			target:   "z",
+			expected: -1,
+		},
+	}
 
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Group(%v, lengthKey) = %v, expected %v", input, result, expected)
-		}
-	})
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := IndexOf(tt.input, tt.target); result != tt.expected {
+				t.Errorf("IndexOf(%v, %q) = %d, expected %d", tt.input, tt.target, result, tt.expected)
+			}
+			if result := Contains(tt.input, tt.target); result != (tt.expected >= 0) {
+				t.Errorf("Contains(%v, %q) = %v, expected %v", tt.input, tt.target, result, tt.expected >= 0)
+			}
 
-	t.Run("group by even/odd", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5, 6}
-		result := Group(input, func(i int) string {
-			if i%2 == 0 {
-				return "even"
+			eq := func(s string) bool { return s == tt.target }
+			if result := IndexFunc(tt.input, eq); result != tt.expected {
+				t.Errorf("IndexFunc(%v) = %d, expected %d", tt.input, result, tt.expected)
+			}
+			if result := ContainsFunc(tt.input, eq); result != (tt.expected >= 0) {
+				t.Errorf("ContainsFunc(%v) = %v, expected %v", tt.input, result, tt.expected >= 0)
 			}
-			return "odd"
 		})
+	}
+}
 
-		expected := map[string][]int{
-			"even": {2, 4, 6},
-			"odd":  {1, 3, 5},
-		}
+func TestAnyAll(t *testing.T) {
+	isPositive := func(n int) bool { return n > 0 }
 
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Group(%v, evenOddKey) = %v, expected %v", input, result, expected)
-		}
-	})
+	tests := map[string]struct {
+		input       []int
+		expectedAny bool
+		expectedAll bool
+	}{
+		"all match":       {input: []int{1, 2, 3}, expectedAny: true, expectedAll: true},
+		"some match":      {input: []int{-1, 2, -3}, expectedAny: true, expectedAll: false},
+		"none match":      {input: []int{-1, -2}, expectedAny: false, expectedAll: false},
+		"empty (vacuous)": {input: []int{}, expectedAny: false, expectedAll: true},
+	}
 
-	t.Run("empty slice", func(t *testing.T) {
-		input := []int{}
-		result := Group(input, func(i int) string {
-			return "key"
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := Any(tt.input, isPositive); result != tt.expectedAny {
+				t.Errorf("Any(%v) = %v, expected %v", tt.input, result, tt.expectedAny)
+			}
+			if result := All(tt.input, isPositive); result != tt.expectedAll {
+				t.Errorf("All(%v) = %v, expected %v", tt.input, result, tt.expectedAll)
+			}
 		})
+	}
 
-		expected := map[string][]int{}
+	t.Run("short-circuits", func(t *testing.T) {
+		input := []int{1, 2, -3, 4, 5}
 
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Group(%v, constantKey) = %v, expected %v", input, result, expected)
+		calls := 0
+		counting := func(n int) bool {
+			calls++
+			return n > 0
 		}
-	})
-
-	t.Run("single group", func(t *testing.T) {
-		input := []int{1, 2, 3, 4}
-		result := Group(input, func(i int) string {
-			return "same"
-		})
 
-		expected := map[string][]int{
-			"same": {1, 2, 3, 4},
+		if !Any(input, counting) || calls != 1 {
+			t.Errorf("Any() made %d predicate calls, expected 1", calls)
 		}
 
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Group(%v, constantKey) = %v, expected %v", input, result, expected)
+		calls = 0
+		if All(input, counting) || calls != 3 {
+			t.Errorf("All() made %d predicate calls, expected 3", calls)
 		}
 	})
 }
 
-type Person struct {
-	Name string
-	Age  int
-}
-
-func TestGroupComplex(t *testing.T) {
-	people := []Person{
-		{"Alice", 30},
-		{"Bob", 25},
-		{"Charlie", 30},
-		{"Diana", 25},
-		{"Eve", 35},
+func TestEqualAndElementsMatch(t *testing.T) {
+	tests := map[string]struct {
+		a, b          []int
+		equal         bool
+		elementsMatch bool
+	}{
+		"identical": {
+			a: []int{1, 2, 3}, b: []int{1, 2, 3},
+			equal: true, elementsMatch: true,
+		},
+		"reordered": {
+			a: []int{1, 2, 3}, b: []int{3, 1, 2},
+			equal: false, elementsMatch: true,
+		},
+		"differing multiplicities": {
+			a: []int{1, 1, 2}, b: []int{1, 2, 2},
+			equal: false, elementsMatch: false,
+		},
+		"differing lengths": {
+			a: []int{1, 2}, b: []int{1, 2, 2},
+			equal: false, elementsMatch: false,
+		},
+		"nil and empty": {
+			a: nil, b: []int{},
+			equal: true, elementsMatch: true,
+		},
 	}
 
-	result := Group(people, func(p Person) int {
-		return p.Age
-	})
-
-	expected := map[int][]Person{
-		25: {{"Bob", 25}, {"Diana", 25}},
-		30: {{"Alice", 30}, {"Charlie", 30}},
-		35: {{"Eve", 35}},
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := Equal(tt.a, tt.b); result != tt.equal {
+				t.Errorf("Equal(%v, %v) = %v, expected %v", tt.a, tt.b, result, tt.equal)
+			}
+			if result := ElementsMatch(tt.a, tt.b); result != tt.elementsMatch {
+				t.Errorf("ElementsMatch(%v, %v) = %v, expected %v", tt.a, tt.b, result, tt.elementsMatch)
+			}
+			if result := ElementsMatch(tt.b, tt.a); result != tt.elementsMatch {
+				t.Errorf("ElementsMatch(%v, %v) = %v, expected %v", tt.b, tt.a, result, tt.elementsMatch)
+			}
+		})
 	}
+}
+func TestIndexFunc_NonComparable(t *testing.T) {
+	input := [][]int{{1}, {2, 3}, {4, 5, 6}, {7, 8}}
+	hasLen2 := func(s []int) bool { return len(s) == 2 }
 
-	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("Group people by age failed: got %v, expected %v", result, expected)
+	if result := IndexFunc(input, hasLen2); result != 1 {
+		t.Errorf("IndexFunc(%v) = %d, expected 1", input, result)
+	}
+	if !ContainsFunc(input, hasLen2) {
+		t.Errorf("ContainsFunc(%v) = false, expected true", input)
+	}
+	if ContainsFunc(nil, hasLen2) {
+		t.Error("ContainsFunc(nil) = true, expected false")
 	}
 }
 
-func TestMapConcurrent(t *testing.T) {
-	t.Run("basic concurrent execution", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5}
-
-		mapFunc := func(ctx context.Context, n int) (string, error) {
-			time.Sleep(10 * time.Millisecond) // Simulate work
-			return strconv.Itoa(n * 2), nil
-		}
+func TestWithout(t *testing.T) {
+	tests := map[string]struct {
+		input    []int
+		remove   []int
+		expected []int
+	}{
+		"remove multiple values": {
+			input:    []int{1, 2, 3, 2, 4, 1, 5},
+			remove:   []int{1, 2},
+			expected: []int{3, 4, 5},
+		},
+		"value not present": {
+			input:    []int{1, 2, 3},
+			remove:   []int{9},
+			expected: []int{1, 2, 3},
+		},
+		"remove everything": {
+			input:    []int{7, 7, 8},
+			remove:   []int{7, 8},
+			expected: []int{},
+		},
+		"nothing to remove": {
+			input:    []int{1, 2},
+			remove:   nil,
+			expected: []int{1, 2},
+		},
+	}
 
-		vv, err := MapConcurrent(mapFunc).Execute(context.Background(), input)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Without(tt.input, tt.remove...)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Without(%v, %v) = %v, expected %v", tt.input, tt.remove, result, tt.expected)
+			}
+		})
+	}
+}
 
-		if err != nil {
-			t.Fatalf("Expected no error, got %v", err)
-		}
+func TestSplit(t *testing.T) {
+	tests := map[string]struct {
+		input    []int
+		expected [][]int
+	}{
+		"separators in the middle": {
+			input:    []int{1, 2, 0, 3, 0, 4, 5},
+			expected: [][]int{{1, 2}, {3}, {4, 5}},
+		},
+		"leading and trailing separators": {
+			input:    []int{0, 1, 0},
+			expected: [][]int{{}, {1}, {}},
+		},
+		"adjacent separators": {
+			input:    []int{1, 0, 0, 2},
+			expected: [][]int{{1}, {}, {2}},
+		},
+		"no separator": {
+			input:    []int{1, 2, 3},
+			expected: [][]int{{1, 2, 3}},
+		},
+		"empty slice": {
+			input:    []int{},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Split(tt.input, 0)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Split(%v, 0) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("appending to a group does not clobber the input", func(t *testing.T) {
+		input := []int{1, 0, 2}
+		groups := Split(input, 0)
+		_ = append(groups[0], 9)
+
+		if !reflect.DeepEqual(input, []int{1, 0, 2}) {
+			t.Errorf("Input modified to %v", input)
+		}
+	})
+}
+
+func TestChunkIntoMode(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tests := map[string]struct {
+		mode          ChunkMode
+		expectedSizes []int
+		expected      [][]int
+	}{
+		"front-loaded": {
+			mode:          ChunkFrontLoaded,
+			expectedSizes: []int{3, 3, 2, 2},
+			expected:      [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8}, {9, 10}},
+		},
+		"back-loaded": {
+			mode:          ChunkBackLoaded,
+			expectedSizes: []int{2, 2, 3, 3},
+			expected:      [][]int{{1, 2}, {3, 4}, {5, 6, 7}, {8, 9, 10}},
+		},
+		"round-robin": {
+			mode:          ChunkRoundRobin,
+			expectedSizes: []int{3, 3, 2, 2},
+			expected:      [][]int{{1, 5, 9}, {2, 6, 10}, {3, 7}, {4, 8}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := ChunkIntoMode(input, 4, tt.mode)
+
+			sizes := Map(result, func(chunk []int) int { return len(chunk) })
+			if !reflect.DeepEqual(sizes, tt.expectedSizes) {
+				t.Errorf("ChunkIntoMode sizes = %v, expected %v", sizes, tt.expectedSizes)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ChunkIntoMode = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("ChunkInto is front-loaded", func(t *testing.T) {
+		if !reflect.DeepEqual(ChunkInto(input, 4), ChunkIntoMode(input, 4, ChunkFrontLoaded)) {
+			t.Errorf("ChunkInto = %v, expected front-loaded chunks", ChunkInto(input, 4))
+		}
+	})
+
+	t.Run("more chunks than elements", func(t *testing.T) {
+		result := ChunkInto([]int{1, 2}, 5)
+		if !reflect.DeepEqual(result, [][]int{{1}, {2}}) {
+			t.Errorf("ChunkInto = %v, expected [[1] [2]]", result)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		if result := ChunkInto([]int{}, 3); result != nil {
+			t.Errorf("ChunkInto([]) = %v, expected nil", result)
+		}
+	})
+
+	t.Run("non-positive count panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for n = 0")
+			}
+		}()
+		ChunkInto(input, 0)
+	})
+}
+
+func TestQuantileBuckets(t *testing.T) {
+	type score struct {
+		Name  string
+		Value int
+	}
+
+	input := []score{
+		{"h", 80}, {"a", 10}, {"e", 50}, {"j", 95}, {"c", 30},
+		{"f", 60}, {"b", 20}, {"i", 90}, {"d", 40}, {"g", 70},
+	}
+	original := slices.Clone(input)
+
+	quartiles := QuantileBuckets(input, func(s score) int { return s.Value }, 4)
+
+	names := Map(quartiles, func(bucket []score) []string {
+		return Map(bucket, func(s score) string { return s.Name })
+	})
+	expected := [][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g", "h"}, {"i", "j"}}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("QuantileBuckets quartiles = %v, expected %v", names, expected)
+	}
+
+	if !reflect.DeepEqual(input, original) {
+		t.Errorf("QuantileBuckets modified its input: %v", input)
+	}
+
+	if result := QuantileBuckets([]score{}, func(s score) int { return s.Value }, 4); result != nil {
+		t.Errorf("QuantileBuckets([]) = %v, expected nil", result)
+	}
+}
+
+func TestChunkStride(t *testing.T) {
+	tests := map[string]struct {
+		input    []int
+		size     int
+		stride   int
+		expected [][]int
+	}{
+		"overlapping": {
+			input:    []int{1, 2, 3, 4, 5},
+			size:     3,
+			stride:   1,
+			expected: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+		},
+		"overlapping with partial tail": {
+			input:    []int{1, 2, 3, 4, 5, 6},
+			size:     3,
+			stride:   2,
+			expected: [][]int{{1, 2, 3}, {3, 4, 5}, {5, 6}},
+		},
+		"non-overlapping": {
+			input:    []int{1, 2, 3, 4, 5},
+			size:     2,
+			stride:   2,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		"gapped": {
+			input:    []int{1, 2, 3, 4, 5, 6, 7},
+			size:     2,
+			stride:   3,
+			expected: [][]int{{1, 2}, {4, 5}, {7}},
+		},
+		"size larger than slice": {
+			input:    []int{1, 2},
+			size:     5,
+			stride:   1,
+			expected: [][]int{{1, 2}},
+		},
+		"empty slice": {
+			input:    []int{},
+			size:     2,
+			stride:   1,
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := ChunkStride(tt.input, tt.size, tt.stride)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ChunkStride(%v, %d, %d) = %v, expected %v", tt.input, tt.size, tt.stride, result, tt.expected)
+			}
+		})
+	}
+
+	for name, args := range map[string][2]int{"zero size": {0, 1}, "zero stride": {1, 0}} {
+		t.Run(name+" panics", func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for size %d, stride %d", args[0], args[1])
+				}
+			}()
+			ChunkStride([]int{1}, args[0], args[1])
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := map[string]struct {
+		input    []int
+		size     int
+		expected [][]int
+	}{
+		"length 5 size 3": {
+			input:    []int{1, 2, 3, 4, 5},
+			size:     3,
+			expected: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+		},
+		"size 1": {
+			input:    []int{1, 2, 3},
+			size:     1,
+			expected: [][]int{{1}, {2}, {3}},
+		},
+		"size equals length": {
+			input:    []int{1, 2, 3},
+			size:     3,
+			expected: [][]int{{1, 2, 3}},
+		},
+		"size exceeds length": {
+			input:    []int{1, 2},
+			size:     3,
+			expected: nil,
+		},
+		"empty slice": {
+			input:    []int{},
+			size:     2,
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := Window(tt.input, tt.size)
+			if err != nil {
+				t.Fatalf("Window(%v, %d) unexpected error: %v", tt.input, tt.size, err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Window(%v, %d) = %v, expected %v", tt.input, tt.size, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("window count formula", func(t *testing.T) {
+		input := make([]int, 10)
+		for size := 1; size <= len(input); size++ {
+			result, err := Window(input, size)
+			if err != nil {
+				t.Fatalf("Window(len 10, %d) unexpected error: %v", size, err)
+			}
+			if len(result) != len(input)-size+1 {
+				t.Errorf("Window(len 10, %d) returned %d windows, expected %d", size, len(result), len(input)-size+1)
+			}
+		}
+	})
+
+	t.Run("non-positive size", func(t *testing.T) {
+		for _, size := range []int{0, -1} {
+			if _, err := Window([]int{1, 2}, size); err == nil {
+				t.Errorf("Window(%d) expected error but got none", size)
+			}
+		}
+	})
+}
+
+func TestRunLengthEncode(t *testing.T) {
+	tests := map[string]struct {
+		input    []string
+		expected []Run[string]
+	}{
+		"with runs": {
+			input:    []string{"a", "a", "a", "b", "c", "c", "a"},
+			expected: []Run[string]{{"a", 3}, {"b", 1}, {"c", 2}, {"a", 1}},
+		},
+		"no runs": {
+			input:    []string{"a", "b", "c"},
+			expected: []Run[string]{{"a", 1}, {"b", 1}, {"c", 1}},
+		},
+		"empty slice": {
+			input:    []string{},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := RunLengthEncode(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("RunLengthEncode(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+
+			// Decoding must reproduce the input
+			decoded := RunLengthDecode(result)
+			if len(tt.input) == 0 {
+				if decoded != nil {
+					t.Errorf("RunLengthDecode(%v) = %v, expected nil", result, decoded)
+				}
+				return
+			}
+			if !reflect.DeepEqual(decoded, tt.input) {
+				t.Errorf("RunLengthDecode(%v) = %v, expected %v", result, decoded, tt.input)
+			}
+		})
+	}
+}
+
+func TestRunLengthDecode(t *testing.T) {
+	runs := []Run[int]{{7, 2}, {8, 0}, {9, -1}, {1, 1}}
+	expected := []int{7, 7, 1}
+
+	if result := RunLengthDecode(runs); !reflect.DeepEqual(result, expected) {
+		t.Errorf("RunLengthDecode(%v) = %v, expected %v", runs, result, expected)
+	}
+}
+
+func TestMap(t *testing.T) {
+	t.Run("int to string", func(t *testing.T) {
+		input := []int{1, 2, 3, 4}
+		expected := []string{"1", "2", "3", "4"}
+		result := Map(input, func(i int) string {
+			return strconv.Itoa(i)
+		})
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Map(%v, intToString) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("string to length", func(t *testing.T) {
+		input := []string{"hello", "world", "go", "test"}
+		expected := []int{5, 5, 2, 4}
+		result := Map(input, func(s string) int {
+			return len(s)
+		})
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Map(%v, stringToLength) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		input := []int{}
+		expected := []string(nil)
+		result := Map(input, func(i int) string {
+			return strconv.Itoa(i)
+		})
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Map(%v, intToString) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("square numbers", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+		expected := []int{1, 4, 9, 16, 25}
+		result := Map(input, func(i int) int {
+			return i * i
+		})
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Map(%v, square) = %v, expected %v", input, result, expected)
+		}
+	})
+}
+
+func TestForEach(t *testing.T) {
+	var indices []int
+	var items []string
+	ForEach([]string{"a", "b", "c"}, func(i int, item string) {
+		indices = append(indices, i)
+		items = append(items, item)
+	})
+
+	if !reflect.DeepEqual(indices, []int{0, 1, 2}) || !reflect.DeepEqual(items, []string{"a", "b", "c"}) {
+		t.Errorf("ForEach visited indices %v and items %v, expected [0 1 2] and [a b c]", indices, items)
+	}
+}
+
+func TestForEachErr(t *testing.T) {
+	errBad := errors.New("bad item")
+
+	t.Run("stops at first error", func(t *testing.T) {
+		var visited []int
+		err := ForEachErr([]int{1, 2, -3, 4, -5}, func(i int, item int) error {
+			visited = append(visited, i)
+			if item < 0 {
+				return errBad
+			}
+			return nil
+		})
+
+		if err == nil || err.Error() != "item 2: bad item" {
+			t.Errorf("ForEachErr error = %v, expected %q", err, "item 2: bad item")
+		}
+		if !errors.Is(err, errBad) {
+			t.Errorf("ForEachErr error = %v, expected to wrap %v", err, errBad)
+		}
+		if !reflect.DeepEqual(visited, []int{0, 1, 2}) {
+			t.Errorf("ForEachErr visited %v, expected [0 1 2]", visited)
+		}
+	})
+
+	t.Run("no errors", func(t *testing.T) {
+		if err := ForEachErr([]int{1, 2}, func(i int, item int) error { return nil }); err != nil {
+			t.Errorf("ForEachErr error = %v, expected nil", err)
+		}
+	})
+}
+
+func TestReverse(t *testing.T) {
+	tests := map[string]struct {
+		input    []int
+		expected []int
+	}{
+		"odd length": {
+			input:    []int{1, 2, 3, 4, 5},
+			expected: []int{5, 4, 3, 2, 1},
+		},
+		"even length": {
+			input:    []int{1, 2, 3, 4},
+			expected: []int{4, 3, 2, 1},
+		},
+		"single element": {
+			input:    []int{1},
+			expected: []int{1},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			original := slices.Clone(tt.input)
+
+			result := Reversed(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Reversed(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+			if !reflect.DeepEqual(tt.input, original) {
+				t.Errorf("Reversed modified its input: %v, expected %v", tt.input, original)
+			}
+
+			Reverse(tt.input)
+			if !reflect.DeepEqual(tt.input, tt.expected) {
+				t.Errorf("Reverse(%v) = %v, expected %v", original, tt.input, tt.expected)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		Reverse([]int{})
+		Reverse[int](nil)
+		if result := Reversed([]int{}); result != nil {
+			t.Errorf("Reversed([]) = %v, expected nil", result)
+		}
+	})
+}
+
+func TestFlatMap(t *testing.T) {
+	t.Run("splitting strings into runes", func(t *testing.T) {
+		input := []string{"ab", "", "cé"}
+		result := FlatMap(input, func(s string) []rune { return []rune(s) })
+		expected := []rune{'a', 'b', 'c', 'é'}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FlatMap(%v) = %q, expected %q", input, result, expected)
+		}
+	})
+
+	t.Run("expanding ranges", func(t *testing.T) {
+		input := []int{3, 0, 2}
+		result := FlatMap(input, func(n int) []int {
+			if n == 0 {
+				return nil
+			}
+			r := make([]int, n)
+			for i := range r {
+				r[i] = i + 1
+			}
+			return r
+		})
+		expected := []int{1, 2, 3, 1, 2}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("FlatMap(%v) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("empty results", func(t *testing.T) {
+		result := FlatMap([]int{1, 2}, func(int) []string { return []string{} })
+		if result != nil {
+			t.Errorf("FlatMap() = %v, expected nil", result)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		result := FlatMap([]int{}, func(n int) []int { return []int{n} })
+		if result != nil {
+			t.Errorf("FlatMap([]) = %v, expected nil", result)
+		}
+	})
+}
+
+func TestFlatten(t *testing.T) {
+	tests := map[string]struct {
+		input    [][]int
+		expected []int
+	}{
+		"ragged inners": {
+			input:    [][]int{{1, 2, 3}, {4}, {5, 6}},
+			expected: []int{1, 2, 3, 4, 5, 6},
+		},
+		"single inner": {
+			input:    [][]int{{1, 2}},
+			expected: []int{1, 2},
+		},
+		"empty and nil inners": {
+			input:    [][]int{{}, nil, {1}, nil},
+			expected: []int{1},
+		},
+		"only empty inners": {
+			input:    [][]int{{}, nil},
+			expected: nil,
+		},
+		"empty outer": {
+			input:    [][]int{},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Flatten(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Flatten(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("inverse of chunking", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7}
+		if result := Flatten(ChunkInto(input, 3)); !reflect.DeepEqual(result, input) {
+			t.Errorf("Flatten(ChunkInto(%v, 3)) = %v, expected %v", input, result, input)
+		}
+	})
+}
+
+func TestConcat(t *testing.T) {
+	tests := map[string]struct {
+		input    [][]int
+		expected []int
+	}{
+		"several slices": {
+			input:    [][]int{{1, 2}, {3}, nil, {4, 5}},
+			expected: []int{1, 2, 3, 4, 5},
+		},
+		"no arguments": {
+			input:    nil,
+			expected: nil,
+		},
+		"only empty slices": {
+			input:    [][]int{{}, nil},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Concat(tt.input...)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Concat(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	tests := map[string]struct {
+		input    [][]string
+		expected []string
+	}{
+		"equal lengths": {
+			input:    [][]string{{"a1", "a2"}, {"b1", "b2"}},
+			expected: []string{"a1", "b1", "a2", "b2"},
+		},
+		"ragged lengths": {
+			input:    [][]string{{"a1", "a2", "a3", "a4"}, {"b1"}, nil, {"c1", "c2"}},
+			expected: []string{"a1", "b1", "c1", "a2", "c2", "a3", "a4"},
+		},
+		"single slice": {
+			input:    [][]string{{"a1", "a2"}},
+			expected: []string{"a1", "a2"},
+		},
+		"no arguments": {
+			input:    nil,
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Interleave(tt.input...)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Interleave(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+func TestFlatten2(t *testing.T) {
+	tests := map[string]struct {
+		input    [][][]int
+		expected []int
+	}{
+		"three levels deep": {
+			input:    [][][]int{{{1, 2}, {3}}, {{4}, {5, 6}}},
+			expected: []int{1, 2, 3, 4, 5, 6},
+		},
+		"empty inner slices": {
+			input:    [][][]int{{{}, nil, {1}}, {}, nil, {{2, 3}, {}}},
+			expected: []int{1, 2, 3},
+		},
+		"no elements": {
+			input:    [][][]int{{{}}, {}},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Flatten2(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Flatten2(%v) = %v, expected %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	t.Run("elementwise sum", func(t *testing.T) {
+		result := ZipWith([]int{1, 2, 3}, []int{10, 20, 30}, func(a, b int) int {
+			return a + b
+		})
+
+		expected := []int{11, 22, 33}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ZipWith sum = %v, expected %v", result, expected)
+		}
+	})
+
+	t.Run("unequal lengths stop at the shorter", func(t *testing.T) {
+		result := ZipWith([]string{"a", "b", "c"}, []int{1, 2}, func(s string, n int) string {
+			return s + strconv.Itoa(n)
+		})
+
+		expected := []string{"a1", "b2"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("ZipWith = %v, expected %v", result, expected)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		result := ZipWith([]int{}, []int{1}, func(a, b int) int { return a + b })
+		if result != nil {
+			t.Errorf("ZipWith with empty input = %v, expected nil", result)
+		}
+	})
+}
+
+func TestZip(t *testing.T) {
+	tests := map[string]struct {
+		keys     []string
+		values   []int
+		expected []Pair[string, int]
+	}{
+		"equal lengths": {
+			keys:     []string{"a", "b", "c"},
+			values:   []int{1, 2, 3},
+			expected: []Pair[string, int]{{"a", 1}, {"b", 2}, {"c", 3}},
+		},
+		"first longer is truncated": {
+			keys:     []string{"a", "b", "c"},
+			values:   []int{1},
+			expected: []Pair[string, int]{{"a", 1}},
+		},
+		"second longer is truncated": {
+			keys:     []string{"a"},
+			values:   []int{1, 2, 3},
+			expected: []Pair[string, int]{{"a", 1}},
+		},
+		"empty": {
+			keys:     nil,
+			values:   []int{1},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Zip(tt.keys, tt.values)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Zip(%v, %v) = %v, expected %v", tt.keys, tt.values, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	keys := []string{"a", "b", "c"}
+	values := []int{1, 2, 3}
+
+	gotKeys, gotValues := Unzip(Zip(keys, values))
+	if !reflect.DeepEqual(gotKeys, keys) || !reflect.DeepEqual(gotValues, values) {
+		t.Errorf("Unzip(Zip(%v, %v)) = %v, %v, expected round trip", keys, values, gotKeys, gotValues)
+	}
+
+	emptyKeys, emptyValues := Unzip[string, int](nil)
+	if emptyKeys != nil || emptyValues != nil {
+		t.Errorf("Unzip(nil) = %v, %v, expected nil, nil", emptyKeys, emptyValues)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := map[string]struct {
+		a        []int
+		b        []int
+		expected []int
+	}{
+		"interleaved": {
+			a:        []int{1, 4, 7, 10},
+			b:        []int{2, 3, 8},
+			expected: []int{1, 2, 3, 4, 7, 8, 10},
+		},
+		"empty first": {
+			a:        []int{},
+			b:        []int{1, 2},
+			expected: []int{1, 2},
+		},
+		"empty second": {
+			a:        []int{1, 2},
+			b:        nil,
+			expected: []int{1, 2},
+		},
+		"duplicates across both": {
+			a:        []int{1, 2, 2, 5},
+			b:        []int{2, 5, 5},
+			expected: []int{1, 2, 2, 2, 5, 5, 5},
+		},
+		"both empty": {
+			a:        nil,
+			b:        []int{},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Merge(tt.a, tt.b)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Merge(%v, %v) = %v, expected %v", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMergeFunc(t *testing.T) {
+	type event struct {
+		Source string
+		At     int
+	}
+
+	a := []event{{"a", 1}, {"a", 3}, {"a", 3}}
+	b := []event{{"b", 2}, {"b", 3}}
+
+	result := MergeFunc(a, b, func(x, y event) int { return x.At - y.At })
+	expected := []event{{"a", 1}, {"b", 2}, {"a", 3}, {"a", 3}, {"b", 3}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("MergeFunc(%v, %v) = %v, expected %v", a, b, result, expected)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	t.Run("mix of successes and failures", func(t *testing.T) {
+		input := []string{"1", "x", "3", "y", "5"}
+		results, errs := Collect(input, strconv.Atoi)
+
+		expected := []int{1, 3, 5}
+		if !reflect.DeepEqual(results, expected) {
+			t.Errorf("Collect results = %v, expected %v", results, expected)
+		}
+		if len(errs) != 2 {
+			t.Fatalf("Expected 2 errors, got %v", errs)
+		}
+		if !strings.Contains(errs[0].Error(), `"x"`) || !strings.Contains(errs[1].Error(), `"y"`) {
+			t.Errorf("Expected errors for x then y, got %v", errs)
+		}
+	})
+
+	t.Run("all succeed", func(t *testing.T) {
+		results, errs := Collect([]string{"7"}, strconv.Atoi)
+		if !reflect.DeepEqual(results, []int{7}) || errs != nil {
+			t.Errorf("Collect = %v, %v, expected [7], nil", results, errs)
+		}
+	})
+}
+
+func TestSplitResults(t *testing.T) {
+	errA := errors.New("a")
+	errC := errors.New("c")
+	results := []string{"", "b", "", "d"}
+	errs := []error{errA, nil, errC, nil}
+
+	successes, failures := SplitResults(results, errs)
+
+	if !reflect.DeepEqual(successes, []string{"b", "d"}) {
+		t.Errorf("SplitResults successes = %v, expected [b d]", successes)
+	}
+	if !reflect.DeepEqual(failures, []error{errA, errC}) {
+		t.Errorf("SplitResults failures = %v, expected [a c]", failures)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	t.Run("group by string length", func(t *testing.T) {
+		input := []string{"hello", "world", "go", "test", "a", "b"}
+		result := Group(input, func(s string) int {
+			return len(s)
+		})
+
+		expected := map[int][]string{
+			1: {"a", "b"},
+			2: {"go"},
+			4: {"test"},
+			5: {"hello", "world"},
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Group(%v, lengthKey) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("group by even/odd", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6}
+		result := Group(input, func(i int) string {
+			if i%2 == 0 {
+				return "even"
+			}
+			return "odd"
+		})
+
+		expected := map[string][]int{
+			"even": {2, 4, 6},
+			"odd":  {1, 3, 5},
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Group(%v, evenOddKey) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		input := []int{}
+		result := Group(input, func(i int) string {
+			return "key"
+		})
+
+		expected := map[string][]int{}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Group(%v, constantKey) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("single group", func(t *testing.T) {
+		input := []int{1, 2, 3, 4}
+		result := Group(input, func(i int) string {
+			return "same"
+		})
+
+		expected := map[string][]int{
+			"same": {1, 2, 3, 4},
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Group(%v, constantKey) = %v, expected %v", input, result, expected)
+		}
+	})
+}
+
+func TestCount(t *testing.T) {
+	tests := map[string]struct {
+		input    []string
+		target   string
+		expected int
+	}{
+		"multiple occurrences": {
+			input:    []string{"a", "b", "a", "c", "a"},
+			target:   "a",
+			expected: 3,
+		},
+		"absent": {
+			input:    []string{"a", "b"},
+			target:   "z",
+			expected: 0,
+		},
+		"empty slice": {
+			input:    nil,
+			target:   "a",
+			expected: 0,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if result := Count(tt.input, tt.target); result != tt.expected {
+				t.Errorf("Count(%v, %q) = %d, expected %d", tt.input, tt.target, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7}
+	evenOdd := func(i int) string {
+		if i%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}
+
+	result := CountBy(input, evenOdd)
+	expected := map[string]int{"even": 3, "odd": 4}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("CountBy(%v, evenOddKey) = %v, expected %v", input, result, expected)
+	}
+
+	// Matches the lengths of the Group-based equivalent
+	for key, group := range Group(input, evenOdd) {
+		if result[key] != len(group) {
+			t.Errorf("CountBy()[%q] = %d, expected len(Group()[%q]) = %d", key, result[key], key, len(group))
+		}
+	}
+
+	if empty := CountBy([]int{}, evenOdd); len(empty) != 0 {
+		t.Errorf("CountBy([]) = %v, expected empty map", empty)
+	}
+}
+
+type Person struct {
+	Name string
+	Age  int
+}
+
+func TestUniqueBy(t *testing.T) {
+	people := []Person{
+		{"Alice", 30},
+		{"Bob", 25},
+		{"Alice", 40},
+		{"Diana", 25},
+		{"Eve", 30},
+	}
+
+	tests := map[string]struct {
+		keyFn    func(Person) any
+		expected []Person
+	}{
+		"by name": {
+			keyFn:    func(p Person) any { return p.Name },
+			expected: []Person{{"Alice", 30}, {"Bob", 25}, {"Diana", 25}, {"Eve", 30}},
+		},
+		"by age": {
+			keyFn:    func(p Person) any { return p.Age },
+			expected: []Person{{"Alice", 30}, {"Bob", 25}, {"Alice", 40}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := UniqueBy(people, tt.keyFn)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("UniqueBy(%v) = %v, expected %v", people, result, tt.expected)
+			}
+		})
+	}
+
+	t.Run("empty slice", func(t *testing.T) {
+		if result := UniqueBy([]Person{}, func(p Person) string { return p.Name }); result != nil {
+			t.Errorf("UniqueBy([]) = %v, expected nil", result)
+		}
+	})
+}
+
+func TestUniqueWithDuplicates(t *testing.T) {
+	tests := map[string]struct {
+		input              []string
+		expectedUnique     []string
+		expectedDuplicates []string
+	}{
+		"value appearing three times": {
+			input:              []string{"a", "b", "a", "c", "a"},
+			expectedUnique:     []string{"a", "b", "c"},
+			expectedDuplicates: []string{"a", "a"},
+		},
+		"duplicates in encounter order": {
+			input:              []string{"x", "y", "y", "x", "z"},
+			expectedUnique:     []string{"x", "y", "z"},
+			expectedDuplicates: []string{"y", "x"},
+		},
+		"no duplicates": {
+			input:              []string{"a", "b"},
+			expectedUnique:     []string{"a", "b"},
+			expectedDuplicates: nil,
+		},
+		"empty slice": {
+			input:              []string{},
+			expectedUnique:     nil,
+			expectedDuplicates: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			unique, duplicates := UniqueWithDuplicates(tt.input)
+			if !reflect.DeepEqual(unique, tt.expectedUnique) {
+				t.Errorf("UniqueWithDuplicates(%v) unique = %v, expected %v", tt.input, unique, tt.expectedUnique)
+			}
+			if !reflect.DeepEqual(duplicates, tt.expectedDuplicates) {
+				t.Errorf("UniqueWithDuplicates(%v) duplicates = %v, expected %v", tt.input, duplicates, tt.expectedDuplicates)
+			}
+		})
+	}
+}
+
+func TestAssociate(t *testing.T) {
+	people := []Person{{"Alice", 30}, {"Bob", 25}, {"Alice", 40}}
+
+	result := Associate(people, func(p Person) (string, int) { return p.Name, p.Age })
+	expected := map[string]int{"Alice": 40, "Bob": 25}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Associate(%v) = %v, expected %v", people, result, expected)
+	}
+
+	empty := Associate([]Person{}, func(p Person) (string, int) { return p.Name, p.Age })
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("Associate([]) = %v, expected empty map", empty)
+	}
+}
+
+func TestKeyBy(t *testing.T) {
+	people := []Person{{"Alice", 30}, {"Bob", 25}, {"Carol", 30}}
+
+	tests := map[string]struct {
+		keyFn    func(Person) any
+		expected map[any]Person
+	}{
+		"unique keys": {
+			keyFn:    func(p Person) any { return p.Name },
+			expected: map[any]Person{"Alice": {"Alice", 30}, "Bob": {"Bob", 25}, "Carol": {"Carol", 30}},
+		},
+		"duplicate keys keep last": {
+			keyFn:    func(p Person) any { return p.Age },
+			expected: map[any]Person{30: {"Carol", 30}, 25: {"Bob", 25}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := KeyBy(people, tt.keyFn)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("KeyBy(%v) = %v, expected %v", people, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	people := []Person{
+		{"Alice", 30},
+		{"Bob", 25},
+		{"Charlie", 35},
+		{"Diana", 25},
+		{"Eve", 30},
+	}
+	byAge := func(p Person) int { return p.Age }
+
+	tests := map[string]struct {
+		sorted   func([]Person, func(Person) int) []Person
+		sort     func([]Person, func(Person) int)
+		expected []Person
+	}{
+		"ascending": {
+			sorted:   SortedBy[Person, int],
+			sort:     SortBy[Person, int],
+			expected: []Person{{"Bob", 25}, {"Diana", 25}, {"Alice", 30}, {"Eve", 30}, {"Charlie", 35}},
+		},
+		"descending": {
+			sorted:   SortedByDesc[Person, int],
+			sort:     SortByDesc[Person, int],
+			expected: []Person{{"Charlie", 35}, {"Alice", 30}, {"Eve", 30}, {"Bob", 25}, {"Diana", 25}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			input := slices.Clone(people)
+
+			result := tt.sorted(input, byAge)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("sorted copy = %v, expected %v", result, tt.expected)
+			}
+			if !reflect.DeepEqual(input, people) {
+				t.Errorf("sorted copy modified its input: %v", input)
+			}
+
+			tt.sort(input, byAge)
+			if !reflect.DeepEqual(input, tt.expected) {
+				t.Errorf("in-place sort = %v, expected %v", input, tt.expected)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		if result := SortedBy([]Person{}, byAge); result != nil {
+			t.Errorf("SortedBy([]) = %v, expected nil", result)
+		}
+	})
+}
+
+func TestMinMaxBy(t *testing.T) {
+	people := []Person{
+		{"Alice", 30},
+		{"Bob", 25},
+		{"Charlie", 35},
+		{"Diana", 25},
+		{"Eve", 35},
+	}
+	byAge := func(p Person) int { return p.Age }
+
+	if youngest, ok := MinBy(people, byAge); !ok || youngest != (Person{"Bob", 25}) {
+		t.Errorf("MinBy(byAge) = %v, %v, expected {Bob 25}, true", youngest, ok)
+	}
+	if oldest, ok := MaxBy(people, byAge); !ok || oldest != (Person{"Charlie", 35}) {
+		t.Errorf("MaxBy(byAge) = %v, %v, expected {Charlie 35}, true", oldest, ok)
+	}
+
+	byName := func(p Person) string { return p.Name }
+	if last, ok := MaxBy(people, byName); !ok || last != (Person{"Eve", 35}) {
+		t.Errorf("MaxBy(byName) = %v, %v, expected {Eve 35}, true", last, ok)
+	}
+
+	if p, ok := MinBy([]Person{}, byAge); ok || p != (Person{}) {
+		t.Errorf("MinBy([]) = %v, %v, expected zero value, false", p, ok)
+	}
+	if p, ok := MaxBy[Person](nil, byAge); ok || p != (Person{}) {
+		t.Errorf("MaxBy(nil) = %v, %v, expected zero value, false", p, ok)
+	}
+}
+
+func TestFind(t *testing.T) {
+	people := []Person{
+		{"Alice", 30},
+		{"Bob", 25},
+		{"Alice", 40},
+	}
+	named := func(name string) func(Person) bool {
+		return func(p Person) bool { return p.Name == name }
+	}
+
+	tests := map[string]struct {
+		pred          func(Person) bool
+		expectedFirst Person
+		expectedLast  Person
+		expectedOK    bool
+	}{
+		"single match": {
+			pred:          named("Bob"),
+			expectedFirst: Person{"Bob", 25},
+			expectedLast:  Person{"Bob", 25},
+			expectedOK:    true,
+		},
+		"two matches": {
+			pred:          named("Alice"),
+			expectedFirst: Person{"Alice", 30},
+			expectedLast:  Person{"Alice", 40},
+			expectedOK:    true,
+		},
+		"no match": {
+			pred:       named("Zed"),
+			expectedOK: false,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if p, ok := Find(people, tt.pred); p != tt.expectedFirst || ok != tt.expectedOK {
+				t.Errorf("Find() = %v, %v, expected %v, %v", p, ok, tt.expectedFirst, tt.expectedOK)
+			}
+			if p, ok := FindLast(people, tt.pred); p != tt.expectedLast || ok != tt.expectedOK {
+				t.Errorf("FindLast() = %v, %v, expected %v, %v", p, ok, tt.expectedLast, tt.expectedOK)
+			}
+		})
+	}
+}
+
+func TestGroupComplex(t *testing.T) {
+	people := []Person{
+		{"Alice", 30},
+		{"Bob", 25},
+		{"Charlie", 30},
+		{"Diana", 25},
+		{"Eve", 35},
+	}
+
+	result := Group(people, func(p Person) int {
+		return p.Age
+	})
+
+	expected := map[int][]Person{
+		25: {{"Bob", 25}, {"Diana", 25}},
+		30: {{"Alice", 30}, {"Charlie", 30}},
+		35: {{"Eve", 35}},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Group people by age failed: got %v, expected %v", result, expected)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	t.Run("summation", func(t *testing.T) {
+		result := Reduce([]int{1, 2, 3, 4}, 0, func(acc, n int) int { return acc + n })
+		if result != 10 {
+			t.Errorf("Reduce(sum) = %d, expected 10", result)
+		}
+	})
+
+	t.Run("string concatenation", func(t *testing.T) {
+		concat := func(acc string, s string) string { return acc + s }
+
+		if result := Reduce([]string{"a", "b", "c"}, ">", concat); result != ">abc" {
+			t.Errorf("Reduce(concat) = %q, expected %q", result, ">abc")
+		}
+		if result := ReduceRight([]string{"a", "b", "c"}, ">", concat); result != ">cba" {
+			t.Errorf("ReduceRight(concat) = %q, expected %q", result, ">cba")
+		}
+	})
+
+	t.Run("building a map", func(t *testing.T) {
+		words := []string{"go", "is", "go"}
+		result := Reduce(words, map[string]int{}, func(acc map[string]int, w string) map[string]int {
+			acc[w]++
+			return acc
+		})
+
+		expected := map[string]int{"go": 2, "is": 1}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Reduce(%v) = %v, expected %v", words, result, expected)
+		}
+	})
+
+	t.Run("right-associative structure", func(t *testing.T) {
+		type node struct {
+			Value int
+			Next  *node
+		}
+
+		list := ReduceRight([]int{1, 2, 3}, (*node)(nil), func(next *node, n int) *node {
+			return &node{Value: n, Next: next}
+		})
+
+		var values []int
+		for n := list; n != nil; n = n.Next {
+			values = append(values, n.Value)
+		}
+		if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+			t.Errorf("ReduceRight(list) = %v, expected [1 2 3]", values)
+		}
+	})
+
+	t.Run("empty returns initial", func(t *testing.T) {
+		fn := func(acc, n int) int { return acc + n }
+		if result := Reduce(nil, 42, fn); result != 42 {
+			t.Errorf("Reduce(nil) = %d, expected 42", result)
+		}
+		if result := ReduceRight([]int{}, 42, fn); result != 42 {
+			t.Errorf("ReduceRight([]) = %d, expected 42", result)
+		}
+	})
+}
+
+func TestReduceWhile(t *testing.T) {
+	// Sum amounts until adding the next one would exceed the budget
+	budgetSum := func(budget int) func(acc, item int) (int, bool) {
+		return func(acc, item int) (int, bool) {
+			if acc+item > budget {
+				return acc, false
+			}
+			return acc + item, true
+		}
+	}
+
+	t.Run("early termination", func(t *testing.T) {
+		input := []int{10, 20, 30, 40}
+		calls := 0
+		sum := budgetSum(45)
+		result := ReduceWhile(input, 0, func(acc, item int) (int, bool) {
+			calls++
+			return sum(acc, item)
+		})
+
+		if result != 30 {
+			t.Errorf("ReduceWhile(%v) = %d, expected 30", input, result)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls before stopping, got %d", calls)
+		}
+	})
+
+	t.Run("full consumption", func(t *testing.T) {
+		input := []int{10, 20, 30, 40}
+		if result := ReduceWhile(input, 0, budgetSum(1000)); result != 100 {
+			t.Errorf("ReduceWhile(%v) = %d, expected 100", input, result)
+		}
+	})
+
+	t.Run("empty slice returns initial", func(t *testing.T) {
+		if result := ReduceWhile([]int{}, 7, budgetSum(10)); result != 7 {
+			t.Errorf("ReduceWhile([]) = %d, expected 7", result)
+		}
+	})
+}
+
+func TestTopK(t *testing.T) {
+	input := []string{"go", "rust", "go", "zig", "rust", "go", "c", "zig"}
+
+	t.Run("clear frequency order", func(t *testing.T) {
+		result := TopK(input, 2)
+
+		expected := []Pair[string, int]{{"go", 3}, {"rust", 2}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("TopK(%v, 2) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("k larger than distinct elements", func(t *testing.T) {
+		result := TopK(input, 10)
+
+		// rust and zig tie; rust appears first
+		expected := []Pair[string, int]{{"go", 3}, {"rust", 2}, {"zig", 2}, {"c", 1}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("TopK(%v, 10) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("non-positive k", func(t *testing.T) {
+		if result := TopK(input, 0); result != nil {
+			t.Errorf("TopK(%v, 0) = %v, expected nil", input, result)
+		}
+	})
+}
+
+func TestIndexMap(t *testing.T) {
+	t.Run("keeps first occurrence of duplicates", func(t *testing.T) {
+		input := []string{"b", "a", "c", "a", "b"}
+		result := IndexMap(input)
+
+		expected := map[string]int{"b": 0, "a": 1, "c": 2}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("IndexMap(%v) = %v, expected %v", input, result, expected)
+		}
+	})
+
+	t.Run("lookups match linear search", func(t *testing.T) {
+		input := []int{5, 3, 5, 7, 3, 9}
+		result := IndexMap(input)
+
+		for _, item := range input {
+			if result[item] != slices.Index(input, item) {
+				t.Errorf("IndexMap(%v)[%d] = %d, expected %d", input, item, result[item], slices.Index(input, item))
+			}
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		result := IndexMap([]int{})
+		if len(result) != 0 {
+			t.Errorf("IndexMap([]) = %v, expected empty map", result)
+		}
+	})
+}
+
+func TestGroupSorted(t *testing.T) {
+	people := []Person{
+		{"Alice", 30},
+		{"Bob", 25},
+		{"Charlie", 30},
+		{"Diana", 25},
+		{"Eve", 35},
+	}
+	byAge := func(p Person) int { return p.Age }
+
+	keys, groups := GroupSorted(people, byAge)
+
+	if !reflect.DeepEqual(keys, []int{25, 30, 35}) {
+		t.Errorf("GroupSorted keys = %v, expected [25 30 35]", keys)
+	}
+	if !reflect.DeepEqual(groups, Group(people, byAge)) {
+		t.Errorf("GroupSorted groups = %v, expected %v", groups, Group(people, byAge))
+	}
+
+	emptyKeys, emptyGroups := GroupSorted([]Person{}, byAge)
+	if len(emptyKeys) != 0 || len(emptyGroups) != 0 {
+		t.Errorf("GroupSorted on empty slice = %v, %v, expected empty", emptyKeys, emptyGroups)
+	}
+}
+
+func TestMapConcurrent(t *testing.T) {
+	t.Run("basic concurrent execution", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+
+		mapFunc := func(ctx context.Context, n int) (string, error) {
+			time.Sleep(10 * time.Millisecond) // Simulate work
+			return strconv.Itoa(n * 2), nil
+		}
+
+		vv, err := MapConcurrent(mapFunc).Execute(context.Background(), input)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []string{"2", "4", "6", "8", "10"}
+		if !reflect.DeepEqual(vv, expected) {
+			t.Errorf("Expected %v, got %v", expected, vv)
+		}
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		input := []int{}
+
+		mapFunc := func(ctx context.Context, n int) (string, error) {
+			return strconv.Itoa(n), nil
+		}
+
+		result, err := MapConcurrent(mapFunc).Execute(context.Background(), input)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if result != nil {
+			t.Errorf("Expected nil mapConcurrentResult for empty input, got %v", result)
+		}
+	})
+
+	t.Run("with custom concurrency", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			time.Sleep(10 * time.Millisecond) // Simulate work
+			return n * n, nil
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithConcurrency(3).
+			Execute(context.Background(), input)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []int{1, 4, 9, 16, 25, 36, 49, 64, 81, 100}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("stop on first error", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			if n == 3 {
+				return 0, errors.New("error at 3")
+			}
+			time.Sleep(50 * time.Millisecond) // Simulate work
+			return n * 2, nil
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithStopOnError(true).
+			Execute(context.Background(), input)
+
+		if err == nil {
+			t.Fatal("Expected error but got none")
+		}
+
+		if err.Error() != "item 2: error at 3" {
+			t.Errorf("Expected 'item 2: error at 3', got '%v'", err)
+		}
+
+		// Result should be nil when there's an error
+		if result != nil {
+			t.Errorf("Expected nil mapConcurrentResult when error occurs, got %v", result)
+		}
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			if n == 3 || n == 4 {
+				return 0, errors.New("error at " + strconv.Itoa(n))
+			}
+			return n * 2, nil
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithStopOnError(false).
+			Execute(context.Background(), input)
+
+		if err == nil {
+			t.Fatal("Expected error but got none")
+		}
+
+		// Partial results are returned: failed indices hold the zero value
+		expected := []int{2, 4, 0, 0, 10}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected partial results %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(100 * time.Millisecond):
+				return n * 2, nil
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		result, err := MapConcurrent(mapFunc).Execute(ctx, input)
+
+		if err == nil {
+			t.Fatal("Expected context cancellation error but got none")
+		}
+
+		// Result should be nil when there's an error (including cancellation)
+		if result != nil {
+			t.Errorf("Expected nil mapConcurrentResult when context is cancelled, got %v", result)
+		}
+	})
+
+	t.Run("order preservation", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+		mapFunc := func(ctx context.Context, n int) (string, error) {
+			// Add variable delay to test ordering
+			delay := time.Duration((11-n)*10) * time.Millisecond
+			time.Sleep(delay)
+			return "item-" + strconv.Itoa(n), nil
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithConcurrency(5).
+			Execute(context.Background(), input)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []string{
+			"item-1", "item-2", "item-3", "item-4", "item-5",
+			"item-6", "item-7", "item-8", "item-9", "item-10",
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Order not preserved. Expected %v, got %v", expected, result)
+		}
+	})
+}
+
+func TestMapConcurrentWorkerPool(t *testing.T) {
+	t.Run("worker pool uses minimum of concurrency and slice length", func(t *testing.T) {
+		// Test with slice smaller than concurrency
+		smallInput := []int{1, 2}
+		concurrentCount := 0
+		maxConcurrent := 0
+		var mu sync.Mutex
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			mu.Lock()
+			concurrentCount++
+			if concurrentCount > maxConcurrent {
+				maxConcurrent = concurrentCount
+			}
+			mu.Unlock()
+
+			time.Sleep(50 * time.Millisecond) // Hold the worker for a bit
+
+			mu.Lock()
+			concurrentCount--
+			mu.Unlock()
+
+			return n * 2, nil
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithConcurrency(8). // Much higher than slice length
+			Execute(context.Background(), smallInput)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []int{2, 4}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+
+		// Should only use 2 workers max (slice length), not 8
+		if maxConcurrent > len(smallInput) {
+			t.Errorf("Expected max %d concurrent workers, but saw %d", len(smallInput), maxConcurrent)
+		}
+
+		// Should have used exactly the slice length as worker count
+		if maxConcurrent != len(smallInput) {
+			t.Errorf("Expected exactly %d concurrent workers, but saw %d", len(smallInput), maxConcurrent)
+		}
+	})
+
+	t.Run("worker pool respects concurrency limit for large slices", func(t *testing.T) {
+		// Test with slice larger than concurrency
+		largeInput := make([]int, 20)
+		for i := range largeInput {
+			largeInput[i] = i + 1
+		}
+
+		concurrentCount := 0
+		maxConcurrent := 0
+		var mu sync.Mutex
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			mu.Lock()
+			concurrentCount++
+			if concurrentCount > maxConcurrent {
+				maxConcurrent = concurrentCount
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond) // Hold the worker for a bit
+
+			mu.Lock()
+			concurrentCount--
+			mu.Unlock()
+
+			return n * 2, nil
+		}
+
+		concurrencyLimit := 3
+		result, err := MapConcurrent(mapFunc).
+			WithConcurrency(concurrencyLimit).
+			Execute(context.Background(), largeInput)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Verify all results are correct
+		if len(result) != len(largeInput) {
+			t.Errorf("Expected mapConcurrentResult length %d, got %d", len(largeInput), len(result))
+		}
+
+		// Should never exceed concurrency limit
+		if maxConcurrent > concurrencyLimit {
+			t.Errorf("Expected max %d concurrent workers, but saw %d", concurrencyLimit, maxConcurrent)
+		}
+
+		// Should have used exactly the concurrency limit
+		if maxConcurrent != concurrencyLimit {
+			t.Errorf("Expected exactly %d concurrent workers, but saw %d", concurrencyLimit, maxConcurrent)
+		}
+	})
+}
+
+func TestMapConcurrentAutoConcurrency(t *testing.T) {
+	prev := runtime.GOMAXPROCS(2)
+	defer runtime.GOMAXPROCS(prev)
+
+	measure := func(input []int) int {
+		concurrentCount := 0
+		maxConcurrent := 0
+		var mu sync.Mutex
+
+		_, err := MapConcurrent(func(ctx context.Context, n int) (int, error) {
+			mu.Lock()
+			concurrentCount++
+			if concurrentCount > maxConcurrent {
+				maxConcurrent = concurrentCount
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond) // Hold the worker for a bit
+
+			mu.Lock()
+			concurrentCount--
+			mu.Unlock()
+
+			return n, nil
+		}).
+			WithConcurrency(AutoConcurrency).
+			Execute(context.Background(), input)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		return maxConcurrent
+	}
+
+	t.Run("caps at GOMAXPROCS", func(t *testing.T) {
+		if maxConcurrent := measure(make([]int, 10)); maxConcurrent != 2 {
+			t.Errorf("Expected exactly 2 concurrent workers, but saw %d", maxConcurrent)
+		}
+	})
+
+	t.Run("never exceeds slice length", func(t *testing.T) {
+		runtime.GOMAXPROCS(4)
+		if maxConcurrent := measure(make([]int, 1)); maxConcurrent != 1 {
+			t.Errorf("Expected exactly 1 concurrent worker, but saw %d", maxConcurrent)
+		}
+	})
+}
+
+func TestMapConcurrentWithProgress(t *testing.T) {
+	input := make([]int, 50)
+	for i := range input {
+		input[i] = i
+	}
+
+	// fn deliberately uses no locking; WithProgress must serialize the calls
+	calls := 0
+	last := 0
+	monotonic := true
+	_, err := MapConcurrent(func(ctx context.Context, n int) (int, error) {
+		if n%10 == 0 {
+			return 0, fmt.Errorf("item %d failed", n)
+		}
+		return n, nil
+	}).
+		WithConcurrency(8).
+		WithStopOnError(false).
+		WithProgress(func(completed, total int) {
+			calls++
+			if completed != last+1 || total != len(input) {
+				monotonic = false
+			}
+			last = completed
+		}).
+		Execute(context.Background(), input)
+
+	if err == nil {
+		t.Fatal("Expected errors for failed items, got nil")
+	}
+	if calls != len(input) {
+		t.Errorf("Expected %d progress calls, got %d", len(input), calls)
+	}
+	if last != len(input) {
+		t.Errorf("Expected final completed count %d, got %d", len(input), last)
+	}
+	if !monotonic {
+		t.Error("Expected completed counts to increase by one with a constant total")
+	}
+}
+
+func TestMapConcurrentExecuteAll(t *testing.T) {
+	mapFunc := func(ctx context.Context, n int) (int, error) {
+		if n%3 == 0 {
+			return 0, fmt.Errorf("error at %d", n)
+		}
+		return n * 2, nil
+	}
+
+	t.Run("parallel results and errors", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7}
+
+		// stopOnError defaults to true and must be ignored
+		results, errs := MapConcurrent(mapFunc).
+			WithConcurrency(2).
+			ExecuteAll(context.Background(), input)
+
+		if len(results) != len(input) || len(errs) != len(input) {
+			t.Fatalf("Expected %d results and errors, got %d and %d", len(input), len(results), len(errs))
+		}
+		for i, n := range input {
+			if n%3 == 0 {
+				if errs[i] == nil || errs[i].Error() != fmt.Sprintf("error at %d", n) {
+					t.Errorf("errs[%d] = %v, expected error at %d", i, errs[i], n)
+				}
+				if results[i] != 0 {
+					t.Errorf("results[%d] = %d, expected zero value", i, results[i])
+				}
+				continue
+			}
+			if errs[i] != nil {
+				t.Errorf("errs[%d] = %v, expected nil", i, errs[i])
+			}
+			if results[i] != n*2 {
+				t.Errorf("results[%d] = %d, expected %d", i, results[i], n*2)
+			}
+		}
+	})
+
+	t.Run("cancelled items report the cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Items either never start and report ErrCancelled, or observe ctx
+		results, errs := MapConcurrent(func(ctx context.Context, n int) (int, error) {
+			return 0, ctx.Err()
+		}).ExecuteAll(ctx, []int{1, 2, 3, 4})
+
+		if len(results) != 4 {
+			t.Fatalf("Expected 4 results, got %d", len(results))
+		}
+		for i, err := range errs {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("errs[%d] = %v, expected context.Canceled", i, err)
+			}
+		}
+	})
+
+	t.Run("validation failure", func(t *testing.T) {
+		results, errs := MapConcurrent(mapFunc).
+			WithValidate(func(n int) error {
+				if n < 0 {
+					return errors.New("negative")
+				}
+				return nil
+			}).
+			ExecuteAll(context.Background(), []int{1, -1})
+
+		if !reflect.DeepEqual(results, []int{0, 0}) {
+			t.Errorf("Expected zero results, got %v", results)
+		}
+		for i, err := range errs {
+			if err == nil || !strings.Contains(err.Error(), "invalid item 1: negative") {
+				t.Errorf("errs[%d] = %v, expected validation error", i, err)
+			}
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		results, errs := MapConcurrent(mapFunc).ExecuteAll(context.Background(), nil)
+		if results != nil || errs != nil {
+			t.Errorf("Expected nil, nil, got %v, %v", results, errs)
+		}
+	})
+}
+
+func TestMapConcurrentFluentAPI(t *testing.T) {
+	t.Run("method chaining", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			return n * 3, nil
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithConcurrency(2).
+			WithStopOnError(false).
+			Execute(context.Background(), input)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []int{3, 6, 9, 12, 15}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("default configuration", func(t *testing.T) {
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			return n + 10, nil
+		}
+
+		handler := MapConcurrent(mapFunc)
+
+		// Check defaults
+		if handler.concurrency != 8 {
+			t.Errorf("Expected default concurrency 8, got %d", handler.concurrency)
+		}
+
+		if !handler.stopOnError {
+			t.Error("Expected default stopOnError to be true")
+		}
+	})
+}
+
+func TestMapConcurrentOrderedCallback(t *testing.T) {
+	t.Run("fires in strict index order", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			// Later items finish first to force buffering
+			time.Sleep(time.Duration(11-n) * 5 * time.Millisecond)
+			return n * 10, nil
+		}
+
+		var indices []int
+		var values []int
+		result, err := MapConcurrent(mapFunc).
+			WithConcurrency(5).
+			WithOrderedCallback(func(index int, value int) {
+				indices = append(indices, index)
+				values = append(values, value)
+			}).
+			Execute(context.Background(), input)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result != nil {
+			t.Errorf("Expected nil results when streaming to a callback, got %v", result)
+		}
+
+		expectedIndices := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		expectedValues := []int{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+		if !reflect.DeepEqual(indices, expectedIndices) {
+			t.Errorf("Expected indices %v, got %v", expectedIndices, indices)
+		}
+		if !reflect.DeepEqual(values, expectedValues) {
+			t.Errorf("Expected values %v, got %v", expectedValues, values)
+		}
+	})
+
+	t.Run("skips failed items exactly once per success", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5}
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			if n == 2 || n == 4 {
+				return 0, errors.New("error at " + strconv.Itoa(n))
+			}
+			return n, nil
+		}
+
+		calls := make(map[int]int)
+		var order []int
+		_, err := MapConcurrent(mapFunc).
+			WithStopOnError(false).
+			WithOrderedCallback(func(index int, value int) {
+				calls[index]++
+				order = append(order, index)
+			}).
+			Execute(context.Background(), input)
+
+		if err == nil {
+			t.Fatal("Expected error but got none")
+		}
+
+		expectedOrder := []int{0, 2, 4}
+		if !reflect.DeepEqual(order, expectedOrder) {
+			t.Errorf("Expected callback order %v, got %v", expectedOrder, order)
+		}
+		for index, n := range calls {
+			if n != 1 {
+				t.Errorf("Expected one callback for index %d, got %d", index, n)
+			}
+		}
+	})
+
+	t.Run("bounds results buffered behind a slow item", func(t *testing.T) {
+		input := make([]int, 100)
+		for i := range input {
+			input[i] = i
+		}
+
+		var mu sync.Mutex
+		completed := 0
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			if n == 0 {
+				time.Sleep(50 * time.Millisecond)
+			}
+			mu.Lock()
+			completed++
+			mu.Unlock()
+			return n, nil
+		}
+
+		// Everything completed before index 0 is delivered was buffered
+		completedAtFirst := -1
+		_, err := MapConcurrent(mapFunc).
+			WithConcurrency(2).
+			WithOrderedCallback(func(index int, value int) {
+				if index == 0 {
+					mu.Lock()
+					completedAtFirst = completed
+					mu.Unlock()
+				}
+			}).
+			Execute(context.Background(), input)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if window := 2 * orderedWindowFactor; completedAtFirst > window {
+			t.Errorf("Expected at most %d items completed before index 0 was delivered, got %d", window, completedAtFirst)
+		}
+	})
+}
+
+func TestMapConcurrentExecuteToWriter(t *testing.T) {
+	t.Run("writes in strict input order", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+		mapFunc := func(ctx context.Context, n int) (string, error) {
+			// Later items finish first to force buffering
+			time.Sleep(time.Duration(11-n) * 5 * time.Millisecond)
+			return strconv.Itoa(n), nil
+		}
+
+		var written []string
+		err := MapConcurrent(mapFunc).
+			WithConcurrency(4).
+			ExecuteToWriter(context.Background(), input, func(s string) error {
+				written = append(written, s)
+				return nil
+			})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+		if !reflect.DeepEqual(written, expected) {
+			t.Errorf("Expected writes %v, got %v", expected, written)
+		}
+	})
 
-		expected := []string{"2", "4", "6", "8", "10"}
-		if !reflect.DeepEqual(vv, expected) {
-			t.Errorf("Expected %v, got %v", expected, vv)
+	t.Run("write error aborts processing", func(t *testing.T) {
+		input := make([]int, 100)
+		for i := range input {
+			input[i] = i
+		}
+
+		var mu sync.Mutex
+		processed := 0
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			mu.Lock()
+			processed++
+			mu.Unlock()
+			return n, nil
+		}
+
+		writeErr := errors.New("disk full")
+		var written []int
+		err := MapConcurrent(mapFunc).
+			WithConcurrency(1).
+			ExecuteToWriter(context.Background(), input, func(n int) error {
+				if n == 3 {
+					return writeErr
+				}
+				written = append(written, n)
+				return nil
+			})
+
+		if !errors.Is(err, writeErr) {
+			t.Fatalf("Expected write error, got %v", err)
+		}
+		if !reflect.DeepEqual(written, []int{0, 1, 2}) {
+			t.Errorf("Expected writes [0 1 2], got %v", written)
+		}
+		if processed == len(input) {
+			t.Errorf("Expected processing to stop early, but all %d items were mapped", processed)
+		}
+	})
+
+	t.Run("bounds pending results behind a slow item", func(t *testing.T) {
+		input := make([]int, 100)
+		for i := range input {
+			input[i] = i
+		}
+
+		var mu sync.Mutex
+		completed := 0
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			if n == 0 {
+				time.Sleep(50 * time.Millisecond)
+			}
+			mu.Lock()
+			completed++
+			mu.Unlock()
+			return n, nil
+		}
+
+		written := 0
+		maxPending := 0
+		err := MapConcurrent(mapFunc).
+			WithConcurrency(2).
+			ExecuteToWriter(context.Background(), input, func(int) error {
+				mu.Lock()
+				maxPending = max(maxPending, completed-written)
+				mu.Unlock()
+				written++
+				return nil
+			})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if written != len(input) {
+			t.Errorf("Expected %d writes, got %d", len(input), written)
+		}
+		if window := 2 * orderedWindowFactor; maxPending > window {
+			t.Errorf("Expected at most %d pending results, got %d", window, maxPending)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		err := MapConcurrent(func(ctx context.Context, n int) (int, error) { return n, nil }).
+			ExecuteToWriter(context.Background(), nil, func(int) error {
+				t.Error("Expected no writes")
+				return nil
+			})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+func TestMapConcurrentExecuteOptional(t *testing.T) {
+	t.Run("distinguishes failures from zero values", func(t *testing.T) {
+		input := []int{0, 1, 2, 3, 4}
+
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			if n == 1 || n == 3 {
+				return 0, errors.New("error at " + strconv.Itoa(n))
+			}
+			return n * 10, nil // n == 0 legitimately maps to zero
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithStopOnError(false).
+			ExecuteOptional(context.Background(), input)
+
+		if err == nil {
+			t.Fatal("Expected error but got none")
+		}
+
+		expected := []Optional[int]{
+			{Value: 0, Present: true},
+			{Value: 0, Present: false},
+			{Value: 20, Present: true},
+			{Value: 0, Present: false},
+			{Value: 40, Present: true},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("all present on success", func(t *testing.T) {
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			return n, nil
+		}
+
+		result, err := MapConcurrent(mapFunc).ExecuteOptional(context.Background(), []int{0, 0})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []Optional[int]{{Value: 0, Present: true}, {Value: 0, Present: true}}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
 		}
 	})
 
 	t.Run("empty slice", func(t *testing.T) {
-		input := []int{}
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			return n, nil
+		}
 
-		mapFunc := func(ctx context.Context, n int) (string, error) {
-			return strconv.Itoa(n), nil
+		result, err := MapConcurrent(mapFunc).ExecuteOptional(context.Background(), nil)
+		if err != nil || result != nil {
+			t.Errorf("Expected nil, nil for empty input, got %v, %v", result, err)
 		}
+	})
+}
 
-		result, err := MapConcurrent(mapFunc).Execute(context.Background(), input)
+func TestMapConcurrentWithValidate(t *testing.T) {
+	errNegative := errors.New("negative input")
+	validate := func(n int) error {
+		if n < 0 {
+			return errNegative
+		}
+		return nil
+	}
+
+	t.Run("invalid element prevents all work", func(t *testing.T) {
+		var calls int
+		var mu sync.Mutex
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+			return n, nil
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithValidate(validate).
+			Execute(context.Background(), []int{1, 2, -3, 4})
+
+		if !errors.Is(err, errNegative) {
+			t.Fatalf("Expected validation error, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "invalid item 2") {
+			t.Errorf("Expected error to identify item 2, got %v", err)
+		}
+		if result != nil {
+			t.Errorf("Expected nil result, got %v", result)
+		}
+		if calls != 0 {
+			t.Errorf("Expected zero mapFunc calls, got %d", calls)
+		}
+	})
+
+	t.Run("valid batch runs normally", func(t *testing.T) {
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			return n * 2, nil
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithValidate(validate).
+			Execute(context.Background(), []int{1, 2, 3})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(result, []int{2, 4, 6}) {
+			t.Errorf("Expected [2 4 6], got %v", result)
+		}
+	})
+}
+
+func TestMapConcurrentSiblingFailureCancelsContext(t *testing.T) {
+	observed := make(chan bool, 1)
+	mapFunc := func(ctx context.Context, n int) (int, error) {
+		if n == 1 {
+			time.Sleep(20 * time.Millisecond)
+			return 0, errors.New("error at 1")
+		}
+
+		select {
+		case <-ctx.Done():
+			observed <- true
+			return 0, ctx.Err()
+		case <-time.After(2 * time.Second):
+			observed <- false
+			return n, nil
+		}
+	}
+
+	start := time.Now()
+	_, err := MapConcurrent(mapFunc).
+		WithConcurrency(2).
+		Execute(context.Background(), []int{1, 2})
+
+	if !<-observed {
+		t.Error("Expected the in-flight item to observe cancellation through its context")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected prompt abort, took %v", elapsed)
+	}
+
+	var mapErr *MapError
+	if !errors.As(err, &mapErr) || !reflect.DeepEqual(mapErr.Indices(), []int{0}) {
+		t.Errorf("Expected *MapError for index 0 only, got %v", err)
+	}
+	if errors.Is(err, ErrCancelled) {
+		t.Errorf("Expected no cancellation error for an internal stop, got %v", err)
+	}
+}
+
+func TestMapConcurrentCancellationError(t *testing.T) {
+	t.Run("normal completion has no context error", func(t *testing.T) {
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			if n == 2 {
+				return 0, errors.New("error at 2")
+			}
+			return n, nil
+		}
+
+		_, err := MapConcurrent(mapFunc).
+			WithStopOnError(false).
+			Execute(context.Background(), []int{1, 2, 3})
+
+		if err == nil {
+			t.Fatal("Expected error but got none")
+		}
+		if errors.Is(err, ErrCancelled) {
+			t.Errorf("Expected no cancellation error, got %v", err)
+		}
+		if err.Error() != "item 1: error at 2" {
+			t.Errorf("Expected only the item error, got %q", err)
+		}
+	})
+
+	t.Run("cancelled completion reports exactly one cancellation", func(t *testing.T) {
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(time.Second):
+				return n, nil
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		_, err := MapConcurrent(mapFunc).
+			WithConcurrency(4).
+			WithStopOnError(false).
+			Execute(ctx, []int{1, 2, 3, 4, 5, 6, 7, 8})
+
+		if !errors.Is(err, ErrCancelled) {
+			t.Fatalf("Expected ErrCancelled, got %v", err)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected error to wrap context.Canceled, got %v", err)
+		}
+		if n := strings.Count(err.Error(), context.Canceled.Error()); n != 1 {
+			t.Errorf("Expected exactly one cancellation in %q, got %d", err, n)
+		}
+		var mapErr *MapError
+		if errors.As(err, &mapErr) {
+			t.Errorf("Expected no item errors, got %v", mapErr)
+		}
+	})
+
+	t.Run("item errors and cancellation without placeholders", func(t *testing.T) {
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			if n%2 == 1 {
+				return 0, fmt.Errorf("error at %d", n)
+			}
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		_, err := MapConcurrent(mapFunc).
+			WithConcurrency(4).
+			WithStopOnError(false).
+			Execute(ctx, []int{1, 2, 3, 4})
+
+		joined, ok := err.(interface{ Unwrap() []error })
+		if !ok {
+			t.Fatalf("Expected joined item and cancellation errors, got %v", err)
+		}
+		if errs := joined.Unwrap(); len(errs) != 2 || errs[0] == nil || errs[1] == nil {
+			t.Errorf("Expected exactly two non-nil errors, got %v", errs)
+		}
+
+		var mapErr *MapError
+		if !errors.As(err, &mapErr) || !reflect.DeepEqual(mapErr.Indices(), []int{0, 2}) {
+			t.Errorf("Expected *MapError for indices [0 2], got %v", err)
+		}
+		if !errors.Is(err, ErrCancelled) || !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected ErrCancelled wrapping context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestMapConcurrentWithResultHook(t *testing.T) {
+	input := []string{"a", "b", "c", "d", "e"}
+
+	mapFunc := func(ctx context.Context, s string) (string, error) {
+		if s == "c" {
+			return "", errors.New("error at c")
+		}
+		return strings.ToUpper(s), nil
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]string)
+	hook := func(index int, item string, result string) string {
+		mu.Lock()
+		seen[index] = item
+		mu.Unlock()
+		return result + "-" + strconv.Itoa(index)
+	}
+
+	result, err := MapConcurrent(mapFunc).
+		WithStopOnError(false).
+		WithResultHook(hook).
+		ExecuteOptional(context.Background(), input)
+
+	if err == nil {
+		t.Fatal("Expected error but got none")
+	}
+
+	values := Map(result, func(o Optional[string]) string { return o.Value })
+	expected := []string{"A-0", "B-1", "", "D-3", "E-4"}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+
+	// The hook sees matching index/item pairs and is skipped for failures
+	expectedSeen := map[int]string{0: "a", 1: "b", 3: "d", 4: "e"}
+	if !reflect.DeepEqual(seen, expectedSeen) {
+		t.Errorf("Expected hook calls %v, got %v", expectedSeen, seen)
+	}
+}
+
+func TestMapConcurrentNilMapFunc(t *testing.T) {
+	t.Run("returns a descriptive error", func(t *testing.T) {
+		result, err := MapConcurrent[int, int](nil).Execute(context.Background(), []int{1, 2, 3})
+
+		if err == nil {
+			t.Fatal("Expected error but got none")
+		}
+		if err.Error() != "mapFunc must not be nil" {
+			t.Errorf("Expected 'mapFunc must not be nil', got %q", err)
+		}
+		if result != nil {
+			t.Errorf("Expected nil result, got %v", result)
+		}
+	})
+
+	t.Run("nil items", func(t *testing.T) {
+		result, err := MapConcurrent[int, int](nil).Execute(context.Background(), nil)
+		if err != nil || result != nil {
+			t.Errorf("Expected nil, nil for nil items, got %v, %v", result, err)
+		}
+	})
+}
+
+func TestMapReduce(t *testing.T) {
+	docs := []string{
+		"the quick brown fox",
+		"the lazy dog",
+		"quick quick fox",
+	}
 
+	countWords := func(ctx context.Context, doc string) (map[string]int, error) {
+		counts := make(map[string]int)
+		for _, word := range strings.Fields(doc) {
+			counts[word]++
+		}
+		return counts, nil
+	}
+	merge := func(acc map[string]int, counts map[string]int) map[string]int {
+		for word, n := range counts {
+			acc[word] += n
+		}
+		return acc
+	}
+
+	t.Run("word count matches sequential baseline", func(t *testing.T) {
+		result, err := MapReduce(context.Background(), docs, countWords, merge, map[string]int{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		baseline := map[string]int{}
+		for _, doc := range docs {
+			counts, _ := countWords(context.Background(), doc)
+			baseline = merge(baseline, counts)
+		}
+
+		if !reflect.DeepEqual(result, baseline) {
+			t.Errorf("Expected %v, got %v", baseline, result)
+		}
+	})
+
+	t.Run("reduces in input order", func(t *testing.T) {
+		toUpper := func(ctx context.Context, s string) (string, error) {
+			time.Sleep(time.Duration(len(s)) * time.Millisecond)
+			return strings.ToUpper(s), nil
+		}
+		concat := func(acc string, s string) string { return acc + s }
+
+		result, err := MapReduce(context.Background(), []string{"ccc", "bb", "a"}, toUpper, concat, "")
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
+		if result != "CCCBBA" {
+			t.Errorf("Expected CCCBBA, got %q", result)
+		}
+	})
+
+	t.Run("propagates map errors", func(t *testing.T) {
+		errBad := errors.New("bad document")
+		failing := func(ctx context.Context, doc string) (map[string]int, error) {
+			if strings.Contains(doc, "lazy") {
+				return nil, errBad
+			}
+			return countWords(ctx, doc)
+		}
 
+		result, err := MapReduce(context.Background(), docs, failing, merge, nil)
+		if !errors.Is(err, errBad) {
+			t.Errorf("Expected map error, got %v", err)
+		}
 		if result != nil {
-			t.Errorf("Expected nil mapConcurrentResult for empty input, got %v", result)
+			t.Errorf("Expected initial value on error, got %v", result)
 		}
 	})
+}
 
-	t.Run("with custom concurrency", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+func TestMapConcurrentWithMaxDuration(t *testing.T) {
+	t.Run("abandons a slow batch promptly", func(t *testing.T) {
+		input := []int{1, 2, 3, 4, 5, 6, 7, 8}
 
+		// Even items are fast, odd items take far longer than the limit
 		mapFunc := func(ctx context.Context, n int) (int, error) {
-			time.Sleep(10 * time.Millisecond) // Simulate work
-			return n * n, nil
+			delay := time.Millisecond
+			if n%2 == 1 {
+				delay = 5 * time.Second
+			}
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(delay):
+				return n * 10, nil
+			}
+		}
+
+		start := time.Now()
+		result, err := MapConcurrent(mapFunc).
+			WithConcurrency(8).
+			WithStopOnError(false).
+			WithMaxDuration(50*time.Millisecond).
+			ExecuteOptional(context.Background(), input)
+		elapsed := time.Since(start)
+
+		if elapsed > time.Second {
+			t.Errorf("Expected prompt return, took %v", elapsed)
+		}
+		if !errors.Is(err, ErrCancelled) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected deadline error, got %v", err)
+		}
+
+		// Completed items are captured
+		for i, o := range result {
+			n := input[i]
+			if n%2 == 0 && (!o.Present || o.Value != n*10) {
+				t.Errorf("Expected completed item %d to be present, got %+v", n, o)
+			}
+			if n%2 == 1 && o.Present {
+				t.Errorf("Expected abandoned item %d to be absent, got %+v", n, o)
+			}
+		}
+	})
+
+	t.Run("fast batch is unaffected", func(t *testing.T) {
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			return n, nil
+		}
+
+		result, err := MapConcurrent(mapFunc).
+			WithMaxDuration(time.Second).
+			Execute(context.Background(), []int{1, 2, 3})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(result, []int{1, 2, 3}) {
+			t.Errorf("Expected [1 2 3], got %v", result)
+		}
+	})
+}
+
+func TestForEachConcurrentIndexed(t *testing.T) {
+	t.Run("visits each index once with bounded concurrency", func(t *testing.T) {
+		input := make([]string, 50)
+		for i := range input {
+			input[i] = "item-" + strconv.Itoa(i)
 		}
 
-		result, err := MapConcurrent(mapFunc).
+		var mu sync.Mutex
+		visits := make(map[int]int)
+		var active, peak int
+
+		err := ForEachConcurrentIndexed(func(ctx context.Context, index int, item string) error {
+			mu.Lock()
+			visits[index]++
+			active++
+			peak = max(peak, active)
+			mu.Unlock()
+
+			if item != input[index] {
+				t.Errorf("Expected item %q at index %d, got %q", input[index], index, item)
+			}
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			active--
+			mu.Unlock()
+			return nil
+		}).
 			WithConcurrency(3).
 			Execute(context.Background(), input)
 
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
-
-		expected := []int{1, 4, 9, 16, 25, 36, 49, 64, 81, 100}
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Expected %v, got %v", expected, result)
+		for i := range input {
+			if visits[i] != 1 {
+				t.Errorf("Expected index %d to be visited once, got %d", i, visits[i])
+			}
+		}
+		if len(visits) != len(input) {
+			t.Errorf("Expected %d visited indices, got %d", len(input), len(visits))
+		}
+		if peak > 3 {
+			t.Errorf("Expected at most 3 concurrent calls, got %d", peak)
 		}
 	})
 
-	t.Run("stop on first error", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5}
-
-		mapFunc := func(ctx context.Context, n int) (int, error) {
-			if n == 3 {
-				return 0, errors.New("error at 3")
+	t.Run("collects errors when not stopping", func(t *testing.T) {
+		err := ForEachConcurrentIndexed(func(ctx context.Context, index int, item int) error {
+			if index%2 == 1 {
+				return errors.New("odd index " + strconv.Itoa(index))
 			}
-			time.Sleep(50 * time.Millisecond) // Simulate work
-			return n * 2, nil
-		}
-
-		result, err := MapConcurrent(mapFunc).
-			WithStopOnError(true).
-			Execute(context.Background(), input)
+			return nil
+		}).
+			WithStopOnError(false).
+			Execute(context.Background(), []int{10, 20, 30, 40})
 
 		if err == nil {
 			t.Fatal("Expected error but got none")
 		}
+		for _, msg := range []string{"odd index 1", "odd index 3"} {
+			if !strings.Contains(err.Error(), msg) {
+				t.Errorf("Expected error to contain %q, got %v", msg, err)
+			}
+		}
+	})
 
-		if err.Error() != "error at 3" {
-			t.Errorf("Expected 'error at 3', got '%v'", err)
+	t.Run("nil function", func(t *testing.T) {
+		if err := ForEachConcurrentIndexed[int](nil).Execute(context.Background(), []int{1}); err == nil {
+			t.Error("Expected error but got none")
 		}
+	})
+}
 
-		// Result should be nil when there's an error
-		if result != nil {
-			t.Errorf("Expected nil mapConcurrentResult when error occurs, got %v", result)
+func TestForEachConcurrent(t *testing.T) {
+	t.Run("visits every item", func(t *testing.T) {
+		var mu sync.Mutex
+		var seen []int
+
+		err := ForEachConcurrent(func(ctx context.Context, n int) error {
+			mu.Lock()
+			seen = append(seen, n)
+			mu.Unlock()
+			return nil
+		}).
+			WithConcurrency(100). // capped at the slice length
+			Execute(context.Background(), []int{3, 1, 2})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		slices.Sort(seen)
+		if !reflect.DeepEqual(seen, []int{1, 2, 3}) {
+			t.Errorf("Expected items [1 2 3], got %v", seen)
 		}
 	})
 
 	t.Run("continue on error", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5}
+		var mu sync.Mutex
+		processed := 0
 
-		mapFunc := func(ctx context.Context, n int) (int, error) {
-			if n == 3 || n == 4 {
-				return 0, errors.New("error at " + strconv.Itoa(n))
+		err := ForEachConcurrent(func(ctx context.Context, n int) error {
+			mu.Lock()
+			processed++
+			mu.Unlock()
+			if n%3 == 0 {
+				return errors.New("error at " + strconv.Itoa(n))
 			}
-			return n * 2, nil
-		}
-
-		result, err := MapConcurrent(mapFunc).
+			return nil
+		}).
+			WithConcurrency(2).
 			WithStopOnError(false).
-			Execute(context.Background(), input)
+			Execute(context.Background(), []int{1, 2, 3, 4, 5, 6})
 
 		if err == nil {
 			t.Fatal("Expected error but got none")
 		}
-
-		// Result should be nil when there's an error
-		if result != nil {
-			t.Errorf("Expected nil mapConcurrentResult when error occurs, got %v", result)
+		if processed != 6 {
+			t.Errorf("Expected all 6 items to be processed, got %d", processed)
+		}
+		for _, msg := range []string{"error at 3", "error at 6"} {
+			if !strings.Contains(err.Error(), msg) {
+				t.Errorf("Expected error to contain %q, got %v", msg, err)
+			}
 		}
 	})
 
 	t.Run("context cancellation", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
-		mapFunc := func(ctx context.Context, n int) (int, error) {
-			select {
-			case <-ctx.Done():
-				return 0, ctx.Err()
-			case <-time.After(100 * time.Millisecond):
-				return n * 2, nil
+		input := make([]int, 100)
+		err := ForEachConcurrent(func(ctx context.Context, n int) error {
+			cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		}).
+			WithConcurrency(2).
+			Execute(ctx, input)
+
+		if !errors.Is(err, ErrCancelled) || !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected ErrCancelled wrapping context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestMapConcurrentMapError(t *testing.T) {
+	errOdd := errors.New("odd")
+	mapFunc := func(ctx context.Context, n int) (int, error) {
+		if n%2 == 1 {
+			return 0, fmt.Errorf("item value %d: %w", n, errOdd)
+		}
+		return n, nil
+	}
+
+	t.Run("exposes per-index errors", func(t *testing.T) {
+		input := []int{2, 3, 4, 5, 6, 7}
+		_, err := MapConcurrent(mapFunc).
+			WithStopOnError(false).
+			Execute(context.Background(), input)
+
+		var mapErr *MapError
+		if !errors.As(err, &mapErr) {
+			t.Fatalf("Expected *MapError, got %T: %v", err, err)
+		}
+
+		if indices := mapErr.Indices(); !reflect.DeepEqual(indices, []int{1, 3, 5}) {
+			t.Errorf("Expected failed indices [1 3 5], got %v", indices)
+		}
+		for _, ie := range mapErr.Errors {
+			expected := fmt.Sprintf("item value %d: odd", input[ie.Index])
+			if ie.Err.Error() != expected {
+				t.Errorf("Expected error %q at index %d, got %q", expected, ie.Index, ie.Err)
 			}
 		}
+		if !errors.Is(err, errOdd) {
+			t.Errorf("Expected errors.Is to match the item error, got %v", err)
+		}
+	})
 
-		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
-		defer cancel()
+	t.Run("single failure is prefixed with its index", func(t *testing.T) {
+		_, err := MapConcurrent(mapFunc).Execute(context.Background(), []int{2, 3})
+		if err == nil || err.Error() != "item 1: item value 3: odd" {
+			t.Errorf("Expected error %q, got %v", "item 1: item value 3: odd", err)
+		}
+	})
 
-		result, err := MapConcurrent(mapFunc).Execute(ctx, input)
+	t.Run("message lists failures in index order", func(t *testing.T) {
+		// Later indices fail first so completion order differs from index order
+		_, err := MapConcurrent(func(ctx context.Context, n int) (int, error) {
+			if n%2 == 0 {
+				return n, nil
+			}
+			time.Sleep(time.Duration(10-n) * 5 * time.Millisecond)
+			return 0, fmt.Errorf("item value %d: %w", n, errOdd)
+		}).
+			WithStopOnError(false).
+			Execute(context.Background(), []int{1, 2, 3, 4, 5})
 
-		if err == nil {
-			t.Fatal("Expected context cancellation error but got none")
+		expected := "item 0: item value 1: odd\nitem 2: item value 3: odd\nitem 4: item value 5: odd"
+		if err == nil || err.Error() != expected {
+			t.Errorf("Expected error %q, got %q", expected, err)
 		}
+	})
 
-		// Result should be nil when there's an error (including cancellation)
-		if result != nil {
-			t.Errorf("Expected nil mapConcurrentResult when context is cancelled, got %v", result)
+	t.Run("for each reports indices", func(t *testing.T) {
+		err := ForEachConcurrent(func(ctx context.Context, n int) error {
+			_, err := mapFunc(ctx, n)
+			return err
+		}).
+			WithStopOnError(false).
+			Execute(context.Background(), []int{1, 2})
+
+		var mapErr *MapError
+		if !errors.As(err, &mapErr) || !reflect.DeepEqual(mapErr.Indices(), []int{0}) {
+			t.Errorf("Expected *MapError with index 0, got %v", err)
 		}
 	})
+}
 
-	t.Run("order preservation", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+func TestMapConcurrentWithRateLimit(t *testing.T) {
+	t.Run("observed rate stays under the limit", func(t *testing.T) {
+		const rate = 50.0
+		input := make([]int, 20)
 
-		mapFunc := func(ctx context.Context, n int) (string, error) {
-			// Add variable delay to test ordering
-			delay := time.Duration((11-n)*10) * time.Millisecond
-			time.Sleep(delay)
-			return "item-" + strconv.Itoa(n), nil
+		var mu sync.Mutex
+		var calls []time.Time
+		mapFunc := func(ctx context.Context, n int) (int, error) {
+			mu.Lock()
+			calls = append(calls, time.Now())
+			mu.Unlock()
+			return n, nil
 		}
 
-		result, err := MapConcurrent(mapFunc).
-			WithConcurrency(5).
+		_, err := MapConcurrent(mapFunc).
+			WithConcurrency(8).
+			WithRateLimit(rate).
 			Execute(context.Background(), input)
-
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
 
-		expected := []string{
-			"item-1", "item-2", "item-3", "item-4", "item-5",
-			"item-6", "item-7", "item-8", "item-9", "item-10",
+		slices.SortFunc(calls, func(a, b time.Time) int { return a.Compare(b) })
+
+		// Any window of 100ms may hold at most rate*0.1 calls plus the one at its start
+		window := 100 * time.Millisecond
+		limit := int(rate*window.Seconds()) + 1
+		for i := range calls {
+			j := i
+			for j < len(calls) && calls[j].Sub(calls[i]) < window {
+				j++
+			}
+			if j-i > limit {
+				t.Fatalf("Observed %d calls within %v, expected at most %d", j-i, window, limit)
+			}
 		}
+	})
 
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Order not preserved. Expected %v, got %v", expected, result)
+	t.Run("cancellation does not hang waiting for a slot", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := MapConcurrent(func(ctx context.Context, n int) (int, error) { return n, nil }).
+			WithRateLimit(1).
+			Execute(ctx, make([]int, 10))
+
+		if !errors.Is(err, ErrCancelled) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected ErrCancelled wrapping context.DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Expected Execute to return promptly after cancellation, took %v", elapsed)
 		}
 	})
 }
 
-func TestMapConcurrentWorkerPool(t *testing.T) {
-	t.Run("worker pool uses minimum of concurrency and slice length", func(t *testing.T) {
-		// Test with slice smaller than concurrency
-		smallInput := []int{1, 2}
-		concurrentCount := 0
-		maxConcurrent := 0
-		var mu sync.Mutex
+func TestMapBatchConcurrent(t *testing.T) {
+	double := func(ctx context.Context, batch []int) ([]int, error) {
+		return Map(batch, func(n int) int { return n * 2 }), nil
+	}
 
-		mapFunc := func(ctx context.Context, n int) (int, error) {
-			mu.Lock()
-			concurrentCount++
-			if concurrentCount > maxConcurrent {
-				maxConcurrent = concurrentCount
+	tests := map[string]struct {
+		size            int
+		expectedBatches []int
+	}{
+		"even batches": {
+			size:            3,
+			expectedBatches: []int{3, 3, 3},
+		},
+		"remainder batch": {
+			size:            4,
+			expectedBatches: []int{4, 4, 1},
+		},
+	}
+
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	expected := []int{2, 4, 6, 8, 10, 12, 14, 16, 18}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var sizes []int
+
+			result, err := MapBatchConcurrent(func(ctx context.Context, batch []int) ([]int, error) {
+				mu.Lock()
+				sizes = append(sizes, len(batch))
+				mu.Unlock()
+				return double(ctx, batch)
+			}).
+				WithBatchSize(tt.size).
+				WithConcurrency(2).
+				Execute(context.Background(), input)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("Expected %v, got %v", expected, result)
 			}
-			mu.Unlock()
 
-			time.Sleep(50 * time.Millisecond) // Hold the worker for a bit
+			slices.Sort(sizes)
+			slices.Reverse(sizes)
+			if !reflect.DeepEqual(sizes, tt.expectedBatches) {
+				t.Errorf("Expected batch sizes %v, got %v", tt.expectedBatches, sizes)
+			}
+		})
+	}
 
-			mu.Lock()
-			concurrentCount--
-			mu.Unlock()
+	t.Run("result length mismatch", func(t *testing.T) {
+		_, err := MapBatchConcurrent(func(ctx context.Context, batch []int) ([]int, error) {
+			return batch[1:], nil
+		}).
+			WithBatchSize(2).
+			Execute(context.Background(), []int{1, 2})
 
-			return n * 2, nil
+		if err == nil || !strings.Contains(err.Error(), "batch returned 1 results for 2 items") {
+			t.Errorf("Expected length mismatch error, got %v", err)
 		}
+	})
 
-		result, err := MapConcurrent(mapFunc).
-			WithConcurrency(8). // Much higher than slice length
-			Execute(context.Background(), smallInput)
+	t.Run("continue on error keeps other batches", func(t *testing.T) {
+		result, err := MapBatchConcurrent(func(ctx context.Context, batch []int) ([]int, error) {
+			if batch[0] == 3 {
+				return nil, errors.New("batch failed")
+			}
+			return double(ctx, batch)
+		}).
+			WithBatchSize(2).
+			WithStopOnError(false).
+			Execute(context.Background(), []int{1, 2, 3, 4, 5})
 
-		if err != nil {
-			t.Fatalf("Expected no error, got %v", err)
+		var mapErr *MapError
+		if !errors.As(err, &mapErr) || !reflect.DeepEqual(mapErr.Indices(), []int{1}) {
+			t.Errorf("Expected *MapError for batch 1, got %v", err)
 		}
-
-		expected := []int{2, 4}
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Expected %v, got %v", expected, result)
+		if !reflect.DeepEqual(result, []int{2, 4, 0, 0, 10}) {
+			t.Errorf("Expected [2 4 0 0 10], got %v", result)
 		}
+	})
 
-		// Should only use 2 workers max (slice length), not 8
-		if maxConcurrent > len(smallInput) {
-			t.Errorf("Expected max %d concurrent workers, but saw %d", len(smallInput), maxConcurrent)
+	t.Run("invalid batch size", func(t *testing.T) {
+		if _, err := MapBatchConcurrent(double).WithBatchSize(0).Execute(context.Background(), input); err == nil {
+			t.Error("Expected error but got none")
 		}
+	})
+}
 
-		// Should have used exactly the slice length as worker count
-		if maxConcurrent != len(smallInput) {
-			t.Errorf("Expected exactly %d concurrent workers, but saw %d", len(smallInput), maxConcurrent)
+func TestChunkConcurrent(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	t.Run("chunk boundaries", func(t *testing.T) {
+		var mu sync.Mutex
+		var chunks [][]int
+
+		err := ChunkConcurrent(func(ctx context.Context, chunk []int) error {
+			mu.Lock()
+			chunks = append(chunks, chunk)
+			mu.Unlock()
+			return nil
+		}).
+			WithChunkSize(4).
+			WithConcurrency(2).
+			Execute(context.Background(), input)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
 		}
-	})
 
-	t.Run("worker pool respects concurrency limit for large slices", func(t *testing.T) {
-		// Test with slice larger than concurrency
-		largeInput := make([]int, 20)
-		for i := range largeInput {
-			largeInput[i] = i + 1
+		slices.SortFunc(chunks, func(a, b []int) int { return a[0] - b[0] })
+		expected := [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10}}
+		if !reflect.DeepEqual(chunks, expected) {
+			t.Errorf("Expected chunks %v, got %v", expected, chunks)
 		}
+	})
 
+	t.Run("respects concurrency limit", func(t *testing.T) {
 		concurrentCount := 0
 		maxConcurrent := 0
 		var mu sync.Mutex
 
-		mapFunc := func(ctx context.Context, n int) (int, error) {
+		concurrencyLimit := 3
+		err := ChunkConcurrent(func(ctx context.Context, chunk []int) error {
 			mu.Lock()
 			concurrentCount++
 			if concurrentCount > maxConcurrent {
@@ -497,72 +3460,63 @@ func TestMapConcurrentWorkerPool(t *testing.T) {
 			concurrentCount--
 			mu.Unlock()
 
-			return n * 2, nil
-		}
-
-		concurrencyLimit := 3
-		result, err := MapConcurrent(mapFunc).
+			return nil
+		}).
+			WithChunkSize(1).
 			WithConcurrency(concurrencyLimit).
-			Execute(context.Background(), largeInput)
+			Execute(context.Background(), input)
 
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
 
-		// Verify all results are correct
-		if len(result) != len(largeInput) {
-			t.Errorf("Expected mapConcurrentResult length %d, got %d", len(largeInput), len(result))
-		}
-
-		// Should never exceed concurrency limit
-		if maxConcurrent > concurrencyLimit {
-			t.Errorf("Expected max %d concurrent workers, but saw %d", concurrencyLimit, maxConcurrent)
-		}
-
-		// Should have used exactly the concurrency limit
 		if maxConcurrent != concurrencyLimit {
 			t.Errorf("Expected exactly %d concurrent workers, but saw %d", concurrencyLimit, maxConcurrent)
 		}
 	})
-}
-
-func TestMapConcurrentFluentAPI(t *testing.T) {
-	t.Run("method chaining", func(t *testing.T) {
-		input := []int{1, 2, 3, 4, 5}
 
-		mapFunc := func(ctx context.Context, n int) (int, error) {
-			return n * 3, nil
-		}
+	t.Run("continue on error reports failed chunks", func(t *testing.T) {
+		var mu sync.Mutex
+		processed := 0
 
-		result, err := MapConcurrent(mapFunc).
-			WithConcurrency(2).
+		err := ChunkConcurrent(func(ctx context.Context, chunk []int) error {
+			mu.Lock()
+			processed++
+			mu.Unlock()
+			if chunk[0] == 3 {
+				return errors.New("insert failed")
+			}
+			return nil
+		}).
+			WithChunkSize(2).
 			WithStopOnError(false).
-			Execute(context.Background(), input)
+			Execute(context.Background(), []int{1, 2, 3, 4, 5})
 
-		if err != nil {
-			t.Fatalf("Expected no error, got %v", err)
+		var mapErr *MapError
+		if !errors.As(err, &mapErr) || !reflect.DeepEqual(mapErr.Indices(), []int{1}) {
+			t.Errorf("Expected *MapError for chunk 1, got %v", err)
 		}
-
-		expected := []int{3, 6, 9, 12, 15}
-		if !reflect.DeepEqual(result, expected) {
-			t.Errorf("Expected %v, got %v", expected, result)
+		if processed != 3 {
+			t.Errorf("Expected 3 chunks processed, got %d", processed)
 		}
 	})
 
-	t.Run("default configuration", func(t *testing.T) {
-		mapFunc := func(ctx context.Context, n int) (int, error) {
-			return n + 10, nil
-		}
-
-		handler := MapConcurrent(mapFunc)
-
-		// Check defaults
-		if handler.concurrency != 8 {
-			t.Errorf("Expected default concurrency 8, got %d", handler.concurrency)
+	t.Run("invalid chunk size", func(t *testing.T) {
+		err := ChunkConcurrent(func(ctx context.Context, chunk []int) error { return nil }).
+			WithChunkSize(0).
+			Execute(context.Background(), input)
+		if err == nil {
+			t.Error("Expected error but got none")
 		}
+	})
 
-		if !handler.stopOnError {
-			t.Error("Expected default stopOnError to be true")
+	t.Run("empty input", func(t *testing.T) {
+		err := ChunkConcurrent(func(ctx context.Context, chunk []int) error {
+			t.Error("chunk function should not be called")
+			return nil
+		}).Execute(context.Background(), nil)
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
 		}
 	})
 }